@@ -0,0 +1,89 @@
+package slog
+
+import "sync"
+
+// asyncLine is a log line queued to be written by an instance, or a flush request when done is set
+type asyncLine struct {
+	i    *slogInstance
+	p    []byte
+	done chan struct{}
+}
+
+// asyncOutput writes the queued lines in a background goroutine
+type asyncOutput struct {
+	queue chan asyncLine
+}
+
+var asyncOut *asyncOutput
+var asyncLock sync.RWMutex
+
+// SetAsyncOutput globally sets if the log lines are written by a background goroutine, so logging does not wait for slow outputs.
+// Up to queueSize lines are held, then logging waits. Zero disables it, writing the held lines (default)
+func SetAsyncOutput(queueSize int) {
+	asyncLock.Lock()
+	defer asyncLock.Unlock()
+
+	if asyncOut != nil {
+		asyncOut.flush()
+		close(asyncOut.queue)
+		asyncOut = nil
+	}
+
+	if queueSize > 0 {
+		asyncOut = &asyncOutput{queue: make(chan asyncLine, queueSize)}
+		go asyncOut.run()
+	}
+}
+
+// AsyncOutputEnabled returns if the log lines are written by a background goroutine
+func AsyncOutputEnabled() bool {
+	asyncLock.RLock()
+	defer asyncLock.RUnlock()
+	return asyncOut != nil
+}
+
+// Flush waits for the held log lines to be written, when the async output is enabled
+func Flush() {
+	asyncLock.RLock()
+	defer asyncLock.RUnlock()
+
+	if asyncOut != nil {
+		asyncOut.flush()
+	}
+}
+
+func (a *asyncOutput) run() {
+	for line := range a.queue {
+		if line.done != nil {
+			close(line.done)
+			continue
+		}
+		_, _ = line.i.Write(line.p)
+	}
+}
+
+func (a *asyncOutput) flush() {
+	done := make(chan struct{})
+	a.queue <- asyncLine{done: done}
+	<-done
+}
+
+// write writes the line with the async output if enabled. FATAL and PANIC lines are written right away, after the held ones,
+// as the program is about to stop
+func (i *slogInstance) write(level LogLevel, p []byte) {
+	asyncLock.RLock()
+	defer asyncLock.RUnlock()
+
+	if asyncOut == nil {
+		_, _ = i.Write(p)
+		return
+	}
+
+	if level == FATAL || level == PANIC {
+		asyncOut.flush()
+		_, _ = i.Write(p)
+		return
+	}
+
+	asyncOut.queue <- asyncLine{i: i, p: p}
+}
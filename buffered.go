@@ -0,0 +1,108 @@
+package slog
+
+import "sync"
+
+// BufferedInstance is a Instance that holds its log lines in memory until Commit or Discard is called
+type BufferedInstance interface {
+	Instance
+	// Commit writes out all held lines. Lines logged after a commit are written directly
+	Commit()
+	// Discard drops all held lines. Lines logged after a discard are held again
+	Discard()
+}
+
+type bufferedLine struct {
+	instance *slogInstance
+	level    LogLevel
+	data     []byte
+}
+
+// logBuffer holds log lines of a buffered instance and all instances derived from it
+type logBuffer struct {
+	sync.Mutex
+	lines     []bufferedLine
+	committed bool
+}
+
+// hold keeps the line in memory and returns true, or returns false if it should be written directly.
+// An ERROR or more severe line commits all the held lines
+func (b *logBuffer) hold(i *slogInstance, level LogLevel, p []byte) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.committed {
+		return false
+	}
+
+	b.lines = append(b.lines, bufferedLine{instance: i, level: level, data: p})
+
+	if atLeast(level, ERROR) {
+		b.flush()
+	}
+
+	return true
+}
+
+func (b *logBuffer) flush() {
+	for _, l := range b.lines {
+		l.instance.write(l.level, l.data)
+	}
+	b.lines = nil
+	b.committed = true
+}
+
+func (b *logBuffer) commit() {
+	b.Lock()
+	defer b.Unlock()
+	b.flush()
+}
+
+func (b *logBuffer) discard() {
+	b.Lock()
+	defer b.Unlock()
+	b.lines = nil
+	b.committed = false
+}
+
+type bufferedInstance struct {
+	*slogInstance
+}
+
+// unbufferedInstance is a Instance from another implementation returned by Buffered. Its lines are not held
+type unbufferedInstance struct {
+	Instance
+}
+
+func (unbufferedInstance) Commit() {}
+
+func (unbufferedInstance) Discard() {}
+
+// Buffered returns a new instance that holds all its log lines (and the ones from its derived instances) in memory until Commit or Discard is called.
+// Logging an ERROR or FATAL message automatically commits the held lines. It is safe to share between goroutines.
+// Instances from other implementations are returned unbuffered, with Commit and Discard doing nothing
+func Buffered(inst Instance) BufferedInstance {
+	var i2 *slogInstance
+	switch v := inst.(type) {
+	case *bufferedInstance:
+		i2 = v.slogInstance.clone()
+	case *slogInstance:
+		i2 = v.clone()
+	case BufferedInstance:
+		return v
+	default:
+		return unbufferedInstance{Instance: v}
+	}
+
+	i2.buffer = &logBuffer{}
+	return &bufferedInstance{slogInstance: i2}
+}
+
+// Commit writes out all held lines. Lines logged after a commit are written directly
+func (b *bufferedInstance) Commit() {
+	b.buffer.commit()
+}
+
+// Discard drops all held lines. Lines logged after a discard are held again
+func (b *bufferedInstance) Discard() {
+	b.buffer.discard()
+}
@@ -0,0 +1,126 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type lockedBuffer struct {
+	sync.Mutex
+	bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.Buffer.String()
+}
+
+func TestBufferedCommit(t *testing.T) {
+	UnsetTestMode()
+	buff := bytes.NewBufferString("")
+	b := Buffered(Scope("Buffered").WithCustomWriter(buff))
+
+	b.Await("Doing some work")
+	b.SubScope("Child").Done("Finished some work")
+
+	if buff.Len() != 0 {
+		t.Fatalf("Expected no output before commit, got %q", buff.String())
+	}
+
+	b.Commit()
+
+	o := buff.String()
+	if !strings.Contains(o, "Doing some work") || !strings.Contains(o, "Finished some work") {
+		t.Errorf("Expected held lines after commit, got %q", o)
+	}
+
+	if strings.Index(o, "Doing some work") > strings.Index(o, "Finished some work") {
+		t.Errorf("Expected held lines in order, got %q", o)
+	}
+
+	buff.Reset()
+	b.Info("After commit")
+	if !strings.Contains(buff.String(), "After commit") {
+		t.Errorf("Expected lines after commit to be written directly, got %q", buff.String())
+	}
+}
+
+func TestBufferedDiscard(t *testing.T) {
+	UnsetTestMode()
+	buff := bytes.NewBufferString("")
+	b := Buffered(Scope("Buffered").WithCustomWriter(buff))
+
+	b.Await("Doing some work")
+	b.Done("Finished some work")
+	b.Discard()
+	b.Commit()
+
+	if buff.Len() != 0 {
+		t.Errorf("Expected no output after discard, got %q", buff.String())
+	}
+}
+
+func TestBufferedAutoCommit(t *testing.T) {
+	UnsetTestMode()
+	buff := bytes.NewBufferString("")
+	b := Buffered(Scope("Buffered").WithCustomWriter(buff))
+
+	b.Await("Doing some work")
+	b.ErrorDone("Work failed")
+
+	o := buff.String()
+	if !strings.Contains(o, "Doing some work") || !strings.Contains(o, "Work failed") {
+		t.Errorf("Expected error to commit held lines, got %q", o)
+	}
+}
+
+func TestBufferedConcurrent(t *testing.T) {
+	UnsetTestMode()
+	buff := &lockedBuffer{}
+	b := Buffered(Scope("Buffered").WithCustomWriter(buff))
+
+	wg := sync.WaitGroup{}
+	for n := 0; n < 10; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			l := b.SubScope("Worker")
+			l.Await("Worker %d started", n)
+			l.Done("Worker %d finished", n)
+		}(n)
+	}
+	wg.Wait()
+	b.Commit()
+
+	if c := strings.Count(buff.String(), LineBreak); c != 20 {
+		t.Errorf("Expected 20 lines got %d", c)
+	}
+}
+
+// foreignInstance is a Instance from another implementation
+type foreignInstance struct {
+	Instance
+}
+
+func TestBufferedForeignInstance(t *testing.T) {
+	UnsetTestMode()
+
+	buff := bytes.NewBufferString("")
+	b := Buffered(foreignInstance{Scope("Foreign").WithCustomWriter(buff)})
+	b.Info("written directly")
+	b.Discard()
+	b.Commit()
+
+	if !strings.Contains(buff.String(), "written directly") {
+		t.Errorf("Expected foreign instance to log unbuffered, got %q", buff.String())
+	}
+}
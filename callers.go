@@ -0,0 +1,195 @@
+package slog
+
+import (
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CallerFormat defines how the caller of a log call is shown
+type CallerFormat int
+
+const (
+	// CallerShort shows the file name and line, like instance.go:38 (default)
+	CallerShort CallerFormat = iota
+	// CallerPackage shows the file relative to its package import path and line, like github.com/quan-to/slog/instance.go:38
+	CallerPackage
+	// CallerFull shows the full file path and line
+	CallerFull
+	// CallerFunction shows the function name, like main.Call0
+	CallerFunction
+)
+
+var (
+	callerFormat     = CallerShort
+	callerLevel      LogLevel
+	jsonCallerFields = false
+	jsonGoroutine    = false
+	jsonPid          = false
+)
+
+// slogDir is the directory of the slog source files. Frames from its non test files are internal to the library
+var slogDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return path.Dir(file)
+}()
+
+// helpers are the functions marked with MarkHelper
+var helpers sync.Map
+
+// SetCallerFormat globally sets how the caller is shown when SetShowLines is enabled. Affects all instances
+func SetCallerFormat(format CallerFormat) {
+	callerFormat = format
+}
+
+// SetCallerLevel sets the minimum level that captures the caller when SetShowLines is enabled, to keep its cost off the lower levels. Defaults to all levels
+func SetCallerLevel(level LogLevel) {
+	callerLevel = level
+}
+
+// SetJSONCallerFields sets if the JSON format shows the caller as a caller object with file, line, function and package fields, instead of the lines string
+func SetJSONCallerFields(enabled bool) {
+	jsonCallerFields = enabled
+}
+
+// SetJSONGoroutine sets if the JSON format shows the id of the goroutine that logged, in the goroutine field
+func SetJSONGoroutine(enabled bool) {
+	jsonGoroutine = enabled
+}
+
+// SetJSONPid sets if the JSON format shows the process id, in the pid field
+func SetJSONPid(enabled bool) {
+	jsonPid = enabled
+}
+
+// MarkHelper marks the calling function as a logging helper, like testing.T.Helper.
+// Helper frames are skipped when looking for the caller of a log call, so wrappers report the line that called them
+func MarkHelper() {
+	pc := make([]uintptr, 1)
+	if runtime.Callers(2, pc) == 0 {
+		return
+	}
+
+	frame, _ := runtime.CallersFrames(pc).Next()
+	helpers.Store(frame.Function, true)
+}
+
+// internalFrame returns if the frame is from the slog library, a helper, the runtime or the standard library loggers
+func internalFrame(frame runtime.Frame) bool {
+	for _, prefix := range []string{"runtime.", "log.", "log/slog."} {
+		if strings.HasPrefix(frame.Function, prefix) {
+			return true
+		}
+	}
+
+	if path.Dir(frame.File) == slogDir && !strings.HasSuffix(frame.File, "_test.go") {
+		return true
+	}
+
+	_, helper := helpers.Load(frame.Function)
+	return helper
+}
+
+// callerFrames returns the frames of pcs from the first one outside the slog library and its helpers, after skipping skip more frames.
+// Returns at most max frames when max is greater than zero
+func callerFrames(pcs []uintptr, skip, max int) []runtime.Frame {
+	var out []runtime.Frame
+	found := false
+
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		found = found || !internalFrame(frame)
+
+		switch {
+		case !found:
+		case skip > 0:
+			skip--
+		default:
+			out = append(out, frame)
+			if max > 0 && len(out) == max {
+				return out
+			}
+		}
+
+		if !more {
+			return out
+		}
+	}
+}
+
+// callerStack returns the program counters of the current goroutine stack
+func callerStack() []uintptr {
+	pc := make([]uintptr, 64)
+	n := runtime.Callers(2, pc)
+	return pc[:n]
+}
+
+// caller returns the caller of the log call, or false if it is not captured for the level
+func (i *slogInstance) caller(level LogLevel) (runtime.Frame, bool) {
+	if !showLines || i.noCaller || !atLeast(level, callerLevel) {
+		return runtime.Frame{}, false
+	}
+
+	if !i.replayTime.IsZero() {
+		return i.replayCaller, i.replayCaller.File != ""
+	}
+
+	frames := callerFrames(callerStack(), i.callerSkip, 1)
+	if len(frames) == 0 {
+		return runtime.Frame{}, false
+	}
+
+	return frames[0], true
+}
+
+// callerFields returns the caller fields of the JSON format
+func callerFields(frame runtime.Frame) map[string]interface{} {
+	return map[string]interface{}{
+		"file":     frame.File,
+		"line":     frame.Line,
+		"function": frame.Function,
+		"package":  packagePath(frame.Function),
+	}
+}
+
+// goroutineID returns the id of the current goroutine, parsed from the stack header like "goroutine 18 [running]:"
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	fields := strings.Fields(string(buf))
+	if len(fields) < 2 {
+		return 0
+	}
+
+	id, _ := strconv.ParseUint(fields[1], 10, 64)
+	return id
+}
+
+func formatCaller(frame runtime.Frame) string {
+	line := ":" + strconv.Itoa(frame.Line)
+
+	switch callerFormat {
+	case CallerPackage:
+		return packagePath(frame.Function) + "/" + path.Base(frame.File) + line
+	case CallerFull:
+		return frame.File + line
+	case CallerFunction:
+		return frame.Function[strings.LastIndex(frame.Function, "/")+1:]
+	}
+
+	return path.Base(frame.File) + line
+}
+
+// packagePath returns the import path of the package of a function name, like github.com/quan-to/slog for github.com/quan-to/slog.Info
+func packagePath(function string) string {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return function
+	}
+	return function[:slash+1+dot]
+}
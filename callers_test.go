@@ -0,0 +1,161 @@
+package slog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// currentLine returns the line that called it
+func currentLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func logHelper(i Instance, msg string) {
+	MarkHelper()
+	i.Info(msg)
+}
+
+func logWrapper(i Instance, msg string) {
+	i.WithCallerSkip(1).Info(msg)
+}
+
+func callerOutput(f func(i Instance) int) (string, int) {
+	buff := bytes.NewBufferString("")
+	SetDefaultOutput(buff)
+	defer SetDefaultOutput(os.Stdout)
+
+	line := f(Scope("Callers"))
+	return stripColors(buff.String()), line
+}
+
+func TestCallerLines(t *testing.T) {
+	UnsetTestMode()
+	SetShowLines(true)
+	defer SetShowLines(false)
+
+	cases := map[string]func(i Instance) int{
+		"Info":    func(i Instance) int { i.Info("x"); return currentLine() },
+		"Await":   func(i Instance) int { i.Await("x"); return currentLine() },
+		"Warnf":   func(i Instance) int { i.Warnf("%s", "x"); return currentLine() },
+		"Errorln": func(i Instance) int { i.Errorln("x"); return currentLine() },
+		"helper":  func(i Instance) int { logHelper(i, "x"); return currentLine() },
+		"wrapper": func(i Instance) int { logWrapper(i, "x"); return currentLine() },
+		"global":  func(i Instance) int { Info("x"); return currentLine() },
+	}
+
+	for name, f := range cases {
+		o, line := callerOutput(f)
+		expected := fmt.Sprintf("| callers_test.go:%d |", line)
+		if !strings.Contains(o, expected) {
+			t.Errorf("%s: expected %q in output: %q", name, expected, o)
+		}
+	}
+}
+
+func TestCallerFormats(t *testing.T) {
+	UnsetTestMode()
+	SetShowLines(true)
+	defer SetShowLines(false)
+	defer SetCallerFormat(CallerShort)
+
+	cases := map[CallerFormat]string{
+		CallerPackage:  "| github.com/quan-to/slog/callers_test.go:%d |",
+		CallerFull:     "/callers_test.go:%d |",
+		CallerFunction: "| slog.TestCallerFormats.func1 |",
+	}
+
+	for format, pattern := range cases {
+		SetCallerFormat(format)
+		o, line := callerOutput(func(i Instance) int { i.Info("x"); return currentLine() })
+
+		expected := pattern
+		if strings.Contains(pattern, "%d") {
+			expected = fmt.Sprintf(pattern, line)
+		}
+
+		if !strings.Contains(o, expected) {
+			t.Errorf("Expected %q in output: %q", expected, o)
+		}
+	}
+}
+
+func TestPackagePath(t *testing.T) {
+	cases := map[string]string{
+		"github.com/quan-to/slog.Info":                 "github.com/quan-to/slog",
+		"github.com/quan-to/slog.(*slogInstance).Info": "github.com/quan-to/slog",
+		"main.main":                      "main",
+		"example.com/a.b/pkg.Func.func1": "example.com/a.b/pkg",
+	}
+
+	for function, expected := range cases {
+		if got := packagePath(function); got != expected {
+			t.Errorf("Expected %q for %q got %q", expected, function, got)
+		}
+	}
+}
+
+func TestJSONCallerFields(t *testing.T) {
+	UnsetTestMode()
+	SetShowLines(true)
+	SetJSONCallerFields(true)
+	SetJSONGoroutine(true)
+	SetJSONPid(true)
+	defer func() {
+		SetShowLines(false)
+		SetJSONCallerFields(false)
+		SetJSONGoroutine(false)
+		SetJSONPid(false)
+	}()
+
+	line := 0
+	values := logJSON(t, func(i Instance) { i.Info("x"); line = currentLine() })
+
+	caller, _ := values["caller"].(map[string]interface{})
+	if !strings.HasSuffix(fmt.Sprint(caller["file"]), "/callers_test.go") || caller["line"] != float64(line) {
+		t.Errorf("Expected caller at callers_test.go:%d got %v", line, caller)
+	}
+
+	if caller["function"] != "github.com/quan-to/slog.TestJSONCallerFields.func2" || caller["package"] != "github.com/quan-to/slog" {
+		t.Errorf("Expected caller function and package got %v", caller)
+	}
+
+	if _, ok := values["lines"]; ok {
+		t.Errorf("Expected no lines field with caller fields")
+	}
+
+	if values["pid"] != float64(os.Getpid()) {
+		t.Errorf("Expected pid %d got %v", os.Getpid(), values["pid"])
+	}
+
+	if id, _ := values["goroutine"].(float64); id == 0 {
+		t.Errorf("Expected goroutine id got %v", values["goroutine"])
+	}
+}
+
+func TestCallerLevel(t *testing.T) {
+	UnsetTestMode()
+	SetShowLines(true)
+	SetCallerLevel(WARN)
+	defer SetShowLines(false)
+	defer SetCallerLevel("")
+
+	values := logJSON(t, func(i Instance) { i.Info("x") })
+	if _, ok := values["lines"]; ok {
+		t.Errorf("Expected no caller below the caller level got %v", values["lines"])
+	}
+
+	values = logJSON(t, func(i Instance) { i.Warn("x") })
+	if !strings.HasPrefix(fmt.Sprint(values["lines"]), "callers_test.go:") {
+		t.Errorf("Expected caller at the caller level got %v", values["lines"])
+	}
+
+	o, _ := callerOutput(func(i Instance) int { i.Info("x"); return 0 })
+	if strings.Contains(o, "callers_test.go") {
+		t.Errorf("Expected no caller column below the caller level: %q", o)
+	}
+}
@@ -0,0 +1,71 @@
+package slog
+
+import "time"
+
+const (
+	// TimeUnixMilli formats the time as the milliseconds since the Unix epoch
+	TimeUnixMilli = "unixmilli"
+	// TimeUnixNano formats the time as the nanoseconds since the Unix epoch
+	TimeUnixNano = "unixnano"
+	// TimeRelative formats the time as the elapsed time since the clock was set (usually the program start), like +1.234s
+	TimeRelative = "relative"
+)
+
+// Clock provides the time of the log lines
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// FixedClock returns a clock that is always at t. Useful for tests
+func FixedClock(t time.Time) Clock {
+	return fixedClock(t)
+}
+
+var (
+	clock        Clock = systemClock{}
+	clockStart         = time.Now()
+	timeFormat         = time.RFC3339
+	timeLocation *time.Location
+)
+
+// SetClock sets the clock used for the log lines time, and resets the start of the TimeRelative format. A nil clock is the system clock
+func SetClock(c Clock) {
+	if c == nil {
+		c = systemClock{}
+	}
+	clock = c
+	clockStart = c.Now()
+}
+
+// SetTimeFormat sets the time layout of the log lines. Accepts time package layouts (like time.RFC3339Nano), TimeUnixMilli, TimeUnixNano and TimeRelative. Defaults to time.RFC3339
+func SetTimeFormat(layout string) {
+	timeFormat = layout
+}
+
+// SetTimeLocation sets the time zone of the log lines, like time.UTC or time.FixedZone. Nil uses the clock time zone (default)
+func SetTimeLocation(loc *time.Location) {
+	timeLocation = loc
+}
+
+// timeValue returns the time as logged in the JSON format. Unix formats are numbers
+func timeValue(t time.Time) interface{} {
+	switch timeFormat {
+	case TimeUnixMilli:
+		return t.UnixMilli()
+	case TimeUnixNano:
+		return t.UnixNano()
+	}
+	return formatTime(t)
+}
@@ -0,0 +1,70 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func resetClock() {
+	SetClock(nil)
+	SetTimeFormat(time.RFC3339)
+	SetTimeLocation(nil)
+}
+
+func TestSetClock(t *testing.T) {
+	UnsetTestMode()
+	defer resetClock()
+
+	now := time.Date(2020, 2, 7, 15, 36, 20, 123456789, time.FixedZone("BRT", -3*3600))
+	SetClock(FixedClock(now))
+
+	buff := bytes.NewBufferString("")
+	i := Scope("Clock").WithCustomWriter(buff)
+
+	i.Info("frozen")
+	if !strings.HasPrefix(buff.String(), "2020-02-07T15:36:20-03:00 |") {
+		t.Errorf("Expected frozen time in output: %q", buff.String())
+	}
+
+	buff.Reset()
+	SetTimeFormat(time.RFC3339Nano)
+	SetTimeLocation(time.UTC)
+	i.Info("utc")
+	if !strings.HasPrefix(buff.String(), "2020-02-07T18:36:20.123456789Z |") {
+		t.Errorf("Expected UTC nano time in output: %q", buff.String())
+	}
+}
+
+func TestTimeFormats(t *testing.T) {
+	defer resetClock()
+
+	now := time.Unix(1581100580, 123456789)
+	SetClock(FixedClock(now.Add(-1500 * time.Millisecond)))
+
+	cases := map[string]string{
+		TimeUnixMilli: "1581100580123",
+		TimeUnixNano:  "1581100580123456789",
+		TimeRelative:  "+1.500s",
+		"15:04":       now.Format("15:04"),
+	}
+
+	for layout, expected := range cases {
+		SetTimeFormat(layout)
+		if got := formatTime(now); got != expected {
+			t.Errorf("Expected %q for layout %q got %q", expected, layout, got)
+		}
+	}
+}
+
+func TestJSONUnixTime(t *testing.T) {
+	defer resetClock()
+
+	SetClock(FixedClock(time.UnixMilli(1581100580123)))
+	SetTimeFormat(TimeUnixMilli)
+
+	if v, ok := timeValue(clock.Now()).(int64); !ok || v != 1581100580123 {
+		t.Errorf("Expected JSON time to be a number got %v", timeValue(clock.Now()))
+	}
+}
@@ -0,0 +1,113 @@
+package slog
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"sync"
+)
+
+// ColorMode defines when the PIPE format output is colored
+type ColorMode int
+
+const (
+	// ColorAuto colors the output only when writing to a terminal, following the NO_COLOR and FORCE_COLOR conventions
+	ColorAuto ColorMode = iota
+	// ColorAlways always colors the output
+	ColorAlways
+	// ColorNever never colors the output
+	ColorNever
+)
+
+var (
+	colorMode    = ColorAuto
+	colorsLock   sync.RWMutex
+	writerColors = map[interface{}]ColorMode{}
+	terminals    sync.Map // *os.File => bool
+)
+
+// SetColorMode sets the color mode for the writers without a mode of their own. Defaults to ColorAuto
+func SetColorMode(mode ColorMode) {
+	colorsLock.Lock()
+	defer colorsLock.Unlock()
+	colorMode = mode
+}
+
+// SetWriterColorMode sets the color mode used when writing to w. A nil writer is the standard output
+func SetWriterColorMode(w io.Writer, mode ColorMode) {
+	key, ok := writerKey(w)
+	if !ok {
+		return
+	}
+
+	colorsLock.Lock()
+	defer colorsLock.Unlock()
+	writerColors[key] = mode
+}
+
+// ColorsEnabled returns if the output written to w is colored. A nil writer is the standard output
+func ColorsEnabled(w io.Writer) bool {
+	if w == nil {
+		w = os.Stdout
+	}
+
+	colorsLock.RLock()
+	mode := colorMode
+	if key, ok := writerKey(w); ok {
+		if m, ok := writerColors[key]; ok {
+			mode = m
+		}
+	}
+	colorsLock.RUnlock()
+
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	if force := os.Getenv("FORCE_COLOR"); force != "" && force != "0" && force != "false" {
+		return true
+	}
+
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return isTerminal(w)
+}
+
+// writerKey returns w as a map key. Writers that can't be compared (like a struct holding a slice) can't have their own mode
+func writerKey(w io.Writer) (interface{}, bool) {
+	if w == nil {
+		return os.Stdout, true
+	}
+	return w, reflect.TypeOf(w).Comparable()
+}
+
+// isTerminal returns if w is a character device, like a terminal. The result is cached for each file
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || f == nil {
+		return false
+	}
+
+	if v, ok := terminals.Load(f); ok {
+		return v.(bool)
+	}
+
+	stat, err := f.Stat()
+	tty := err == nil && stat.Mode()&os.ModeCharDevice != 0
+	terminals.Store(f, tty)
+	return tty
+}
+
+// colors returns if the instance output is colored
+func (i *slogInstance) colors() bool {
+	return !i.noColors && ColorsEnabled(i.customOut)
+}
@@ -0,0 +1,112 @@
+package slog
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// sliceWriter is a writer that can't be used as a map key
+type sliceWriter struct {
+	lines []string
+}
+
+func (w sliceWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func hasColors(s string) bool {
+	return stripColors(s) != s
+}
+
+func TestColorMode(t *testing.T) {
+	UnsetTestMode()
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	defer SetColorMode(ColorAuto)
+
+	buff := bytes.NewBufferString("")
+	i := Scope("Colors").WithCustomWriter(buff)
+
+	i.Info("auto")
+	if hasColors(buff.String()) {
+		t.Errorf("Expected no colors when not writing to a terminal: %q", buff.String())
+	}
+
+	if !strings.Contains(buff.String(), "| I | MSG   |") {
+		t.Errorf("Expected plain pipe layout: %q", buff.String())
+	}
+
+	buff.Reset()
+	SetColorMode(ColorAlways)
+	i.Info("always")
+	if !hasColors(buff.String()) {
+		t.Errorf("Expected colors with ColorAlways: %q", buff.String())
+	}
+
+	buff.Reset()
+	i.LogNoFormat("no format")
+	if hasColors(buff.String()) {
+		t.Errorf("Expected LogNoFormat to have no colors: %q", buff.String())
+	}
+
+	buff.Reset()
+	SetWriterColorMode(buff, ColorNever)
+	i.Info("never")
+	if hasColors(buff.String()) {
+		t.Errorf("Expected writer mode to override the global mode: %q", buff.String())
+	}
+}
+
+func TestColorEnv(t *testing.T) {
+	defer SetColorMode(ColorAuto)
+	buff := bytes.NewBufferString("")
+
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+	if !ColorsEnabled(buff) {
+		t.Errorf("Expected FORCE_COLOR to enable colors")
+	}
+
+	t.Setenv("FORCE_COLOR", "0")
+	if ColorsEnabled(buff) {
+		t.Errorf("Expected FORCE_COLOR=0 to not enable colors")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("FORCE_COLOR", "1")
+	if ColorsEnabled(buff) {
+		t.Errorf("Expected NO_COLOR to disable colors")
+	}
+
+	SetColorMode(ColorAlways)
+	if !ColorsEnabled(buff) {
+		t.Errorf("Expected ColorAlways to override NO_COLOR")
+	}
+}
+
+func TestWriterColorModeNotComparable(t *testing.T) {
+	w := sliceWriter{}
+	SetWriterColorMode(w, ColorAlways)
+
+	if ColorsEnabled(w) {
+		t.Errorf("Expected no colors for a writer without its own mode")
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if isTerminal(f) {
+		t.Errorf("Expected a regular file to not be a terminal")
+	}
+
+	if isTerminal(bytes.NewBufferString("")) {
+		t.Errorf("Expected a buffer to not be a terminal")
+	}
+}
@@ -0,0 +1,142 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cmd is an exec.Cmd whose output is logged line by line: stdout in INFO level and stderr in WARN level
+type Cmd struct {
+	*exec.Cmd
+	inst    Instance
+	stdout  *LineWriter
+	stderr  *LineWriter
+	start   time.Time
+	logArgs bool
+}
+
+// Command returns a command like exec.Command, logged by an instance sub scoped by the command name.
+// Run and Start log an AWAIT line with the command path, and Wait logs a DONE line with the exit code and duration
+func Command(inst Instance, name string, arg ...string) *Cmd {
+	return newCmd(inst, exec.Command(name, arg...))
+}
+
+// CommandContext returns a command like exec.CommandContext, logged like in Command
+func CommandContext(ctx context.Context, inst Instance, name string, arg ...string) *Cmd {
+	return newCmd(inst, exec.CommandContext(ctx, name, arg...))
+}
+
+func newCmd(inst Instance, cmd *exec.Cmd) *Cmd {
+	l := inst.SubScope(filepath.Base(cmd.Path))
+	c := &Cmd{
+		Cmd:    cmd,
+		inst:   l,
+		stdout: l.LineWriter(INFO, MSG),
+		stderr: l.LineWriter(WARN, MSG),
+	}
+	cmd.Stdout = c.stdout
+	cmd.Stderr = c.stderr
+	return c
+}
+
+// Run starts the command and waits for it to finish
+func (c *Cmd) Run() error {
+	if err := c.Start(); err != nil {
+		return err
+	}
+	return c.Wait()
+}
+
+// SetLogArgs sets if the AWAIT line shows the command arguments, which may carry secrets. Only the path is shown by default
+func (c *Cmd) SetLogArgs(enabled bool) *Cmd {
+	c.logArgs = enabled
+	return c
+}
+
+// Start starts the command, logging an AWAIT line
+func (c *Cmd) Start() error {
+	c.start = clock.Now()
+	if c.logArgs {
+		c.inst.Await("Running %s", c.Cmd.String())
+	} else {
+		c.inst.Await("Running %s", c.Cmd.Path)
+	}
+
+	if err := c.Cmd.Start(); err != nil {
+		c.inst.ErrorDone("Failed to start: %s", err)
+		return err
+	}
+	return nil
+}
+
+// Wait waits for the command to finish, logging the last partial output lines and a DONE line with the exit code and duration.
+// Commands that fail, or exit with a code other than zero, are logged with ErrorDone
+func (c *Cmd) Wait() error {
+	err := c.Cmd.Wait()
+	_ = c.stdout.Close()
+	_ = c.stderr.Close()
+
+	fields := map[string]interface{}{
+		"exitCode": c.ProcessState.ExitCode(),
+		"duration": clock.Now().Sub(c.start).String(),
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		c.inst.WithFields(fields).Done("Finished")
+	case errors.As(err, &exitErr):
+		c.inst.WithFields(fields).ErrorDone("Failed with exit code %d", exitErr.ExitCode())
+	default:
+		c.inst.WithFields(fields).ErrorDone("Failed: %s", err)
+	}
+	return err
+}
+
+// Output runs the command and returns its stdout, which is also logged. As in exec.Cmd, the stderr is kept in the *exec.ExitError returned,
+// unless Stderr was replaced
+func (c *Cmd) Output() ([]byte, error) {
+	var out bytes.Buffer
+	c.Cmd.Stdout = io.MultiWriter(&out, c.stdout)
+
+	var stderr *outputBuffer
+	if c.Cmd.Stderr == c.stderr {
+		stderr = &outputBuffer{}
+		c.Cmd.Stderr = io.MultiWriter(stderr, c.stderr)
+	}
+
+	err := c.Run()
+
+	var exitErr *exec.ExitError
+	if stderr != nil && errors.As(err, &exitErr) {
+		exitErr.Stderr = stderr.buf.Bytes()
+	}
+	return out.Bytes(), err
+}
+
+// CombinedOutput runs the command and returns its stdout and stderr, which are also logged
+func (c *Cmd) CombinedOutput() ([]byte, error) {
+	out := &outputBuffer{}
+	c.Cmd.Stdout = io.MultiWriter(out, c.stdout)
+	c.Cmd.Stderr = io.MultiWriter(out, c.stderr)
+	err := c.Run()
+	return out.buf.Bytes(), err
+}
+
+// outputBuffer is a bytes.Buffer written by the stdout and stderr copying goroutines
+type outputBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *outputBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
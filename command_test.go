@@ -0,0 +1,157 @@
+package slog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestHelperProcess is the child process of the command tests. It prints its arguments after -- and exits with the code in SLOG_HELPER_EXIT
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("SLOG_HELPER_PROCESS") != "1" {
+		return
+	}
+
+	fmt.Fprint(os.Stdout, "hello\nworld")
+	fmt.Fprint(os.Stderr, "careful\n")
+
+	code := 0
+	_, _ = fmt.Sscan(os.Getenv("SLOG_HELPER_EXIT"), &code)
+	os.Exit(code)
+}
+
+func helperCommand(inst Instance, exitCode int) *Cmd {
+	cmd := Command(inst, os.Args[0], "-test.run=TestHelperProcess")
+	cmd.Env = append(os.Environ(), ProfileEnv+"=", "SLOG_HELPER_PROCESS=1", fmt.Sprintf("SLOG_HELPER_EXIT=%d", exitCode))
+	return cmd
+}
+
+func TestCommand(t *testing.T) {
+	UnsetTestMode()
+	SetLogFormat(JSON)
+	defer SetLogFormat(PIPE)
+
+	buff := &lockedBuffer{}
+	if err := helperCommand(Scope("Tools").WithCustomWriter(buff), 0).Run(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	entries := jsonEntries(t, buff)
+	if len(entries) != 5 {
+		t.Fatalf("Expected 5 lines got %v", entries)
+	}
+
+	scope := "Tools - " + filepath.Base(os.Args[0])
+	for _, e := range entries {
+		if e["scope"] != scope {
+			t.Errorf("Expected scope %q got %v", scope, e)
+		}
+	}
+
+	if entries[0]["op"] != "AWAIT" || entries[0]["msg"] != "Running "+os.Args[0] {
+		t.Errorf("Expected AWAIT line without arguments got %v", entries[0])
+	}
+
+	// stdout and stderr are copied concurrently, so their lines may interleave
+	var output []string
+	for _, e := range entries[1:4] {
+		output = append(output, fmt.Sprintf("%s %s", e["level"], e["msg"]))
+	}
+	sort.Strings(output)
+	if strings.Join(output, ",") != "info hello,info world,warn careful" {
+		t.Errorf("Expected output lines got %q", output)
+	}
+
+	last := entries[4]
+	if last["op"] != "DONE" || last["level"] != "info" || last["exitCode"] != float64(0) || last["duration"] == nil {
+		t.Errorf("Expected DONE line with exit code and duration got %v", last)
+	}
+}
+
+func TestCommandExitCode(t *testing.T) {
+	UnsetTestMode()
+	SetLogFormat(JSON)
+	defer SetLogFormat(PIPE)
+
+	buff := &lockedBuffer{}
+	err := helperCommand(Scope("Tools").WithCustomWriter(buff), 3).Run()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Fatalf("Expected exit code 3 got %v", err)
+	}
+
+	entries := jsonEntries(t, buff)
+	last := entries[len(entries)-1]
+	if last["op"] != "DONE" || last["level"] != "error" || last["exitCode"] != float64(3) || last["msg"] != "Failed with exit code 3" {
+		t.Errorf("Expected ErrorDone line with exit code 3 got %v", last)
+	}
+}
+
+func TestCommandNotFound(t *testing.T) {
+	UnsetTestMode()
+
+	buff := &lockedBuffer{}
+	if err := Command(Scope("Tools").WithCustomWriter(buff), "slog-missing-command").Run(); err == nil {
+		t.Fatalf("Expected error")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buff.String()), LineBreak)
+	if len(lines) != 2 || !strings.Contains(lines[1], "Failed to start: ") || !strings.Contains(lines[1], "slog-missing-command") {
+		t.Errorf("Expected start failure line got %q", lines)
+	}
+}
+
+func TestCommandCombinedOutput(t *testing.T) {
+	UnsetTestMode()
+
+	buff := &lockedBuffer{}
+	out, err := helperCommand(Scope("Tools").WithCustomWriter(buff), 0).CombinedOutput()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, s := range []string{"hello\nworld", "careful\n"} {
+		if !strings.Contains(string(out), s) {
+			t.Errorf("Expected %q in output %q", s, out)
+		}
+	}
+
+	if !strings.Contains(buff.String(), "careful") {
+		t.Errorf("Expected output also logged got %q", buff.String())
+	}
+}
+
+func TestCommandLogArgs(t *testing.T) {
+	UnsetTestMode()
+
+	buff := &lockedBuffer{}
+	if err := helperCommand(Scope("Tools").WithCustomWriter(buff), 0).SetLogArgs(true).Run(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !strings.Contains(buff.String(), "Running "+os.Args[0]+" -test.run=TestHelperProcess") {
+		t.Errorf("Expected AWAIT line with arguments got %q", buff.String())
+	}
+}
+
+func TestCommandOutputStderr(t *testing.T) {
+	UnsetTestMode()
+
+	buff := &lockedBuffer{}
+	out, err := helperCommand(Scope("Tools").WithCustomWriter(buff), 3).Output()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || string(exitErr.Stderr) != "careful\n" {
+		t.Fatalf("Expected exit error with stderr got %v", err)
+	}
+
+	if string(out) != "hello\nworld" || !strings.Contains(buff.String(), "careful") {
+		t.Errorf("Expected stdout returned and stderr logged got %q and %q", out, buff.String())
+	}
+}
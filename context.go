@@ -0,0 +1,24 @@
+package slog
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries the instance
+func NewContext(ctx context.Context, inst Instance) context.Context {
+	return context.WithValue(ctx, contextKey{}, inst)
+}
+
+// LookupContext returns the instance carried by ctx, and if there is one
+func LookupContext(ctx context.Context) (Instance, bool) {
+	inst, ok := ctx.Value(contextKey{}).(Instance)
+	return inst, ok
+}
+
+// FromContext returns the instance carried by ctx, or the global instance if there is none
+func FromContext(ctx context.Context) Instance {
+	if inst, ok := LookupContext(ctx); ok {
+		return inst
+	}
+	return glog
+}
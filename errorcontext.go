@@ -0,0 +1,95 @@
+package slog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	errorScopeField = "errorScope"
+	errorTagField   = "errorTag"
+)
+
+// contextError is a error created by Errorf. It carries the context of the instance that created it
+type contextError struct {
+	err    error
+	scope  []string
+	tag    string
+	fields map[string]interface{}
+	logged int32
+}
+
+func (e *contextError) Error() string {
+	return e.err.Error()
+}
+
+func (e *contextError) Unwrap() error {
+	return e.err
+}
+
+func (e *contextError) isLogged() bool {
+	return atomic.LoadInt32(&e.logged) == 1
+}
+
+func (e *contextError) markLogged() {
+	atomic.StoreInt32(&e.logged, 1)
+}
+
+// alreadyLogged returns if the error, or any error it wraps, was created by Errorf and already logged
+func alreadyLogged(err error) bool {
+	var ce *contextError
+	return errors.As(err, &ce) && ce.isLogged()
+}
+
+// errorOnly returns if the message is a single error, without arguments
+func errorOnly(str interface{}, v []interface{}) bool {
+	if args, ok := str.(printlnArgs); ok && len(args) == 1 {
+		str, v = args[0], nil
+	}
+
+	_, ok := str.(error)
+	return ok && len(v) == 0
+}
+
+// Errorf logs out a message in ERROR level and returns it as an error carrying the instance scope, tag and fields. Supports %w to wrap errors.
+// Logging the returned error again (or any error wrapping it) as the only message is skipped, except in FATAL and PANIC levels.
+// Messages with it as argument are logged without the error field. Use ErrorFields to get its context
+func (i *slogInstance) Errorf(format string, v ...interface{}) error {
+	err := &contextError{
+		err:    fmt.Errorf(format, v...),
+		scope:  i.scope,
+		tag:    i.tag,
+		fields: i.fields,
+	}
+
+	i.record(ERROR, err, nil)
+	if enabledLevels[ERROR] {
+		i.log(err, ERROR)
+		err.markLogged()
+	}
+
+	return err
+}
+
+// ErrorFields returns the fields, scope and tag carried by a error created by Errorf (or any error wrapping it), plus the error itself in the error field.
+// Returns nil if the error doesn't carry any context
+func ErrorFields(err error) map[string]interface{} {
+	var ce *contextError
+	if !errors.As(err, &ce) {
+		return nil
+	}
+
+	fields := map[string]interface{}{
+		errorField:      err,
+		errorScopeField: strings.Join(ce.scope, " > "),
+		errorTagField:   ce.tag,
+	}
+
+	for k, v := range ce.fields {
+		fields[k] = v
+	}
+
+	return fields
+}
@@ -0,0 +1,98 @@
+package slog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestErrorf(t *testing.T) {
+	UnsetTestMode()
+	defer SetFieldRepresentation(fieldRepresentation)
+	SetFieldRepresentation(JSONFields)
+	buff := bytes.NewBufferString("")
+	i := Scope("Errorf").SubScope("Save").Tag("REQ1").WithFields(map[string]interface{}{
+		"user": "huebr",
+	}).WithCustomWriter(buff)
+
+	base := errors.New("connection refused")
+	err := i.Errorf("cannot save user: %w", base)
+
+	if err.Error() != "cannot save user: connection refused" {
+		t.Errorf("Got %q want %q.", err.Error(), "cannot save user: connection refused")
+	}
+
+	if !errors.Is(err, base) {
+		t.Errorf("Expected returned error to wrap the base error")
+	}
+
+	if strings.Count(buff.String(), LineBreak) != 1 || !strings.Contains(buff.String(), "cannot save user") {
+		t.Errorf("Expected error to be logged once, got %q", buff.String())
+	}
+
+	// Logging the same error again, or a error wrapping it, is skipped
+	buff.Reset()
+	i.Error(err)
+	i.Error(fmt.Errorf("handler: %w", err))
+	if buff.Len() != 0 {
+		t.Errorf("Expected already logged error to be skipped, got %q", buff.String())
+	}
+
+	// Messages with the error as argument are logged, without the error field
+	i.Error("Request failed for user %s: %v", "bob", err)
+	if !strings.Contains(buff.String(), "Request failed for user bob: cannot save user") || strings.Contains(buff.String(), `"error"`) {
+		t.Errorf("Expected message without the error field, got %q", buff.String())
+	}
+
+	fields := ErrorFields(fmt.Errorf("handler: %w", err))
+	if fields["errorScope"] != "Errorf > Save" {
+		t.Errorf("Got %q want %q.", fields["errorScope"], "Errorf > Save")
+	}
+
+	if fields["errorTag"] != "REQ1" {
+		t.Errorf("Got %q want %q.", fields["errorTag"], "REQ1")
+	}
+
+	if fields["user"] != "huebr" {
+		t.Errorf("Got %q want %q.", fields["user"], "huebr")
+	}
+
+	// Logging with the error fields works
+	buff.Reset()
+	Scope("Handler").WithCustomWriter(buff).WithFields(fields).Error("Request failed")
+	if !strings.Contains(buff.String(), "errorScope") {
+		t.Errorf("Expected error context in output, got %q", buff.String())
+	}
+}
+
+func TestErrorFieldsWithoutContext(t *testing.T) {
+	if ErrorFields(errors.New("plain")) != nil {
+		t.Errorf("Expected nil fields for errors without context")
+	}
+}
+
+func TestErrorfChain(t *testing.T) {
+	UnsetTestMode()
+	SetErrorWithStack(true)
+	defer SetErrorWithStack(false)
+
+	err := &contextError{err: fmt.Errorf("cannot save user: %w", errors.New("connection refused"))}
+
+	values := logJSON(t, func(i Instance) {
+		i.Error(fmt.Errorf("handler: %w", err))
+	})
+
+	e := values["error"].(map[string]interface{})
+	chain, _ := e["chain"].([]interface{})
+	if len(chain) != 2 {
+		t.Fatalf("Expected chain without the internal wrappers, got %v", e["chain"])
+	}
+
+	for n, expected := range []string{"cannot save user: connection refused", "connection refused"} {
+		if msg := chain[n].(map[string]interface{})["message"]; msg != expected {
+			t.Errorf("Got %q want %q.", msg, expected)
+		}
+	}
+}
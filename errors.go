@@ -0,0 +1,226 @@
+package slog
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"runtime"
+)
+
+const errorField = "error"
+
+var errorWithStack = false
+
+// SetErrorWithStack globally sets if the stack of the log call is captured when logging an error that does not carry its own stack trace. Affects all instances
+func SetErrorWithStack(enabled bool) {
+	errorWithStack = enabled
+}
+
+// ErrorWithStackEnabled returns if the stack of the log call is captured when logging an error
+func ErrorWithStackEnabled() bool {
+	return errorWithStack
+}
+
+// logSiteError carries the stack of the log call that logged the error
+type logSiteError struct {
+	error
+	callers []uintptr
+	skip    int
+}
+
+func (e *logSiteError) Unwrap() error {
+	return e.error
+}
+
+// findError returns the first error between the message and its arguments
+func findError(str interface{}, v []interface{}) error {
+	if err, ok := str.(error); ok {
+		return err
+	}
+
+	if args, ok := str.(printlnArgs); ok {
+		v = args
+	}
+
+	for _, a := range v {
+		if err, ok := a.(error); ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withError returns a instance with the error field set. It replaces a error field in the instance fields
+func (i *slogInstance) withError(err error) *slogInstance {
+	if errorWithStack && !hasStack(err) {
+		err = &logSiteError{error: err, callers: callerStack(), skip: i.callerSkip}
+	}
+
+	fields := map[string]interface{}{}
+	for k, v := range i.fields {
+		fields[k] = v
+	}
+	fields[errorField] = err
+
+	i2 := i.clone()
+	i2.fields = fields
+	return i2
+}
+
+// expandErrors returns a copy of the fields where the error values are replaced by their message, type, chain and stack
+func expandErrors(fields map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		if err, ok := v.(error); ok {
+			v = errorDescription(err)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+func errorDescription(err error) map[string]interface{} {
+	stack := errorStack(err)
+	if e, ok := err.(*logSiteError); ok {
+		err = e.error
+	}
+	if e, ok := err.(*contextError); ok {
+		err = e.err
+	}
+
+	desc := map[string]interface{}{
+		"message": err.Error(),
+		"type":    fmt.Sprintf("%T", err),
+	}
+
+	var chain []map[string]interface{}
+	for _, e := range unwrapAll(err) {
+		chain = append(chain, map[string]interface{}{
+			"message": e.Error(),
+			"type":    fmt.Sprintf("%T", e),
+		})
+
+		if len(stack) == 0 {
+			stack = errorStack(e)
+		}
+	}
+
+	if len(chain) > 0 {
+		desc["chain"] = chain
+	}
+
+	if len(stack) > 0 {
+		desc["stack"] = stack
+	}
+
+	return desc
+}
+
+// unwrapAll returns all errors wrapped by err, in depth-first order. Supports both errors.Unwrap and errors.Join.
+// The wrappers added by the package, which only repeat the wrapped message, are left out
+func unwrapAll(err error) []error {
+	var wrapped []error
+
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		wrapped = e.Unwrap()
+	default:
+		wrapped = []error{errors.Unwrap(err)}
+	}
+
+	var out []error
+	for _, w := range wrapped {
+		if w == nil {
+			continue
+		}
+		if !internalError(w) {
+			out = append(out, w)
+		}
+		out = append(out, unwrapAll(w)...)
+	}
+
+	return out
+}
+
+// internalError returns if the error is a wrapper added by the package
+func internalError(err error) bool {
+	switch err.(type) {
+	case *contextError, *logSiteError:
+		return true
+	}
+	return false
+}
+
+// errorStack returns the stack trace exposed by the error type, if any.
+// Supports the StackTrace() method from github.com/pkg/errors and the Callers() method from github.com/go-errors/errors
+func errorStack(err error) []string {
+	if e, ok := err.(*logSiteError); ok {
+		var stack []string
+		for _, frame := range callerFrames(e.callers, e.skip, 0) {
+			stack = append(stack, formatFrame(frame))
+		}
+		return stack
+	}
+
+	v := reflect.ValueOf(err)
+	for _, name := range []string{"StackTrace", "Callers"} {
+		m := v.MethodByName(name)
+		if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+			continue
+		}
+
+		t := m.Type().Out(0)
+		if t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Uintptr {
+			continue
+		}
+
+		r := m.Call(nil)[0]
+		pcs := make([]uintptr, r.Len())
+		for n := range pcs {
+			pcs[n] = uintptr(r.Index(n).Uint())
+		}
+		return formatCallers(pcs)
+	}
+
+	return nil
+}
+
+func hasStack(err error) bool {
+	if len(errorStack(err)) > 0 {
+		return true
+	}
+
+	for _, e := range unwrapAll(err) {
+		if len(errorStack(e)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatCallers(pcs []uintptr) []string {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	var stack []string
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			stack = append(stack, formatFrame(frame))
+		}
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
+
+func formatFrame(frame runtime.Frame) string {
+	return fmt.Sprintf("%s %s:%d", frame.Function, path.Base(frame.File), frame.Line)
+}
@@ -0,0 +1,135 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+type stackTraceError struct {
+	pcs []uintptr
+}
+
+func (e *stackTraceError) Error() string {
+	return "stack trace error"
+}
+
+func (e *stackTraceError) StackTrace() []uintptr {
+	return e.pcs
+}
+
+func newStackTraceError() error {
+	pc := make([]uintptr, 32)
+	n := runtime.Callers(1, pc)
+	return &stackTraceError{pcs: pc[:n]}
+}
+
+func logJSON(t *testing.T, f func(i Instance)) map[string]interface{} {
+	SetLogFormat(JSON)
+	defer SetLogFormat(PIPE)
+
+	buff := bytes.NewBufferString("")
+	f(Scope("Errors").WithCustomWriter(buff))
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(buff.Bytes(), &values); err != nil {
+		t.Fatalf("Cannot parse output %q: %s", buff.String(), err)
+	}
+	return values
+}
+
+func TestErrorFields(t *testing.T) {
+	UnsetTestMode()
+	base := errors.New("base error")
+	wrapped := fmt.Errorf("wrapped: %w", base)
+
+	values := logJSON(t, func(i Instance) {
+		i.Error("Operation failed: %s", wrapped)
+	})
+
+	e, ok := values["error"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected error field, got %v", values)
+	}
+
+	if e["message"] != "wrapped: base error" {
+		t.Errorf("Got %q want %q.", e["message"], "wrapped: base error")
+	}
+
+	if e["type"] != "*fmt.wrapError" {
+		t.Errorf("Got %q want %q.", e["type"], "*fmt.wrapError")
+	}
+
+	chain, _ := e["chain"].([]interface{})
+	if len(chain) != 1 || chain[0].(map[string]interface{})["message"] != "base error" {
+		t.Errorf("Expected chain with base error, got %v", e["chain"])
+	}
+}
+
+func TestErrorFieldsJoin(t *testing.T) {
+	UnsetTestMode()
+	joined := errors.Join(errors.New("first"), errors.New("second"))
+
+	values := logJSON(t, func(i Instance) {
+		i.WithFields(map[string]interface{}{"cause": joined}).Warn("Something happened")
+	})
+
+	e, ok := values["cause"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected error value to be expanded, got %v", values["cause"])
+	}
+
+	chain, _ := e["chain"].([]interface{})
+	if len(chain) != 2 {
+		t.Errorf("Expected chain with 2 errors, got %v", e["chain"])
+	}
+}
+
+func TestErrorStackTrace(t *testing.T) {
+	UnsetTestMode()
+	values := logJSON(t, func(i Instance) {
+		i.Error(fmt.Errorf("wrapped: %w", newStackTraceError()))
+	})
+
+	e := values["error"].(map[string]interface{})
+	stack, _ := e["stack"].([]interface{})
+	if len(stack) == 0 || !strings.Contains(stack[0].(string), "newStackTraceError") {
+		t.Errorf("Expected stack from the error type, got %v", e["stack"])
+	}
+}
+
+func TestErrorWithStack(t *testing.T) {
+	UnsetTestMode()
+	SetErrorWithStack(true)
+	defer SetErrorWithStack(false)
+
+	values := logJSON(t, func(i Instance) {
+		i.Error(errors.New("no stack"))
+	})
+
+	e := values["error"].(map[string]interface{})
+	if e["type"] != "*errors.errorString" {
+		t.Errorf("Got %q want %q.", e["type"], "*errors.errorString")
+	}
+
+	stack, _ := e["stack"].([]interface{})
+	if len(stack) == 0 || !strings.Contains(stack[0].(string), "TestErrorWithStack") {
+		t.Errorf("Expected stack starting at the log call, got %v", e["stack"])
+	}
+}
+
+func TestErrorFieldReplaced(t *testing.T) {
+	UnsetTestMode()
+	values := logJSON(t, func(i Instance) {
+		i.WithFields(map[string]interface{}{"error": "custom"}).Error("Failed: %v", errors.New("real error"))
+	})
+
+	e, ok := values["error"].(map[string]interface{})
+	if !ok || e["message"] != "real error" {
+		t.Errorf("Expected logged error to replace the error field, got %v", values["error"])
+	}
+}
@@ -0,0 +1,71 @@
+package slog
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+var exitFunc = os.Exit
+var exitCode = 1
+var exitHookTimeout = 5 * time.Second
+
+var exitHooks []func()
+var exitHooksLock sync.Mutex
+
+// RegisterExitHook registers a function to be called before the program is closed by Fatal. Hooks are called in reverse order of registration, like deferred calls
+func RegisterExitHook(hook func()) {
+	exitHooksLock.Lock()
+	defer exitHooksLock.Unlock()
+	exitHooks = append(exitHooks, hook)
+}
+
+// SetExitFunc sets the function called by Fatal to close the program. Defaults to os.Exit
+func SetExitFunc(f func(int)) {
+	if f == nil {
+		f = os.Exit
+	}
+	exitFunc = f
+}
+
+// SetExitCode sets the exit code used by Fatal. Defaults to 1
+func SetExitCode(code int) {
+	exitCode = code
+}
+
+// SetExitHookTimeout sets how long Fatal waits for the exit hooks to finish before closing the program. Defaults to 5 seconds
+func SetExitHookTimeout(timeout time.Duration) {
+	exitHookTimeout = timeout
+}
+
+func runExitHooks() {
+	exitHooksLock.Lock()
+	hooks := make([]func(), len(exitHooks))
+	copy(hooks, exitHooks)
+	exitHooksLock.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for n := len(hooks) - 1; n >= 0; n-- {
+			func() {
+				defer func() {
+					_ = recover() // A broken hook should not prevent the others to run
+				}()
+				hooks[n]()
+			}()
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(exitHookTimeout):
+	}
+}
+
+// exit runs the exit hooks and closes the program
+func exit() {
+	runExitHooks()
+	exitFunc(exitCode)
+}
@@ -0,0 +1,89 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExitHooks(t *testing.T) {
+	code := -1
+	SetExitFunc(func(c int) {
+		code = c
+	})
+	SetExitCode(3)
+	defer func() {
+		SetExitFunc(nil)
+		SetExitCode(1)
+		exitHooks = nil
+	}()
+
+	var calls []string
+	RegisterExitHook(func() {
+		calls = append(calls, "first")
+	})
+	RegisterExitHook(func() {
+		panic("broken hook")
+	})
+	RegisterExitHook(func() {
+		calls = append(calls, "last")
+	})
+
+	buff := bytes.NewBufferString("")
+	Scope("ExitHooks").WithCustomWriter(buff).Fatal("Test %s", "fatal")
+
+	if code != 3 {
+		t.Errorf("Expected exit code 3 got %d", code)
+	}
+
+	if strings.Join(calls, ",") != "last,first" {
+		t.Errorf("Expected hooks in reverse order, got %v", calls)
+	}
+
+	if !strings.Contains(buff.String(), "Test fatal") {
+		t.Errorf("Expected fatal message in output: %q", buff.String())
+	}
+}
+
+func TestExitHookTimeout(t *testing.T) {
+	exited := false
+	SetExitFunc(func(int) {
+		exited = true
+	})
+	SetExitHookTimeout(10 * time.Millisecond)
+	defer func() {
+		SetExitFunc(nil)
+		SetExitHookTimeout(5 * time.Second)
+		exitHooks = nil
+	}()
+
+	block := make(chan struct{})
+	defer close(block)
+	RegisterExitHook(func() {
+		<-block
+	})
+
+	Scope("ExitHooks").WithCustomWriter(bytes.NewBufferString("")).Fatal("Test fatal")
+
+	if !exited {
+		t.Errorf("Expected exit after hook timeout")
+	}
+}
+
+func TestPanic(t *testing.T) {
+	buff := bytes.NewBufferString("")
+	i := Scope("Panic").WithCustomWriter(buff)
+
+	defer func() {
+		r := recover()
+		if r != "Test huebr 1" {
+			t.Errorf("Expected panic value %q got %v", "Test huebr 1", r)
+		}
+		if !strings.Contains(buff.String(), "Test huebr 1") {
+			t.Errorf("Expected panic message in output: %q", buff.String())
+		}
+	}()
+
+	i.Panic("Test %s %d", "huebr", 1)
+}
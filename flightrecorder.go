@@ -0,0 +1,213 @@
+package slog
+
+import (
+	"container/list"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const replayedField = "replayed"
+
+// flightRecord is a log call captured by the flight recorder. The message is formatted when recorded, so later changes to the arguments are not replayed
+type flightRecord struct {
+	instance *slogInstance
+	level    LogLevel
+	msg      string
+	err      error
+	time     time.Time
+	caller   runtime.Frame
+}
+
+// flightBuffer is a fixed size ring of flight records
+type flightBuffer struct {
+	key     string
+	records []flightRecord
+	start   int
+	count   int
+}
+
+func (b *flightBuffer) push(r flightRecord) {
+	if b.count < len(b.records) {
+		b.records[(b.start+b.count)%len(b.records)] = r
+		b.count++
+		return
+	}
+
+	b.records[b.start] = r
+	b.start = (b.start + 1) % len(b.records)
+}
+
+func (b *flightBuffer) drain() []flightRecord {
+	out := make([]flightRecord, b.count)
+	for n := 0; n < b.count; n++ {
+		out[n] = b.records[(b.start+n)%len(b.records)]
+	}
+	return out
+}
+
+type flightRecorder struct {
+	sync.Mutex
+	enabled bool
+	size    int
+	maxTags int
+	perTag  bool
+	trigger LogLevel
+	buffers map[string]*list.Element
+	lru     *list.List
+}
+
+var recorder = &flightRecorder{
+	size:    100,
+	maxTags: 1024,
+	perTag:  true,
+	trigger: ERROR,
+	buffers: map[string]*list.Element{},
+	lru:     list.New(),
+}
+
+func (r *flightRecorder) key(tag string) string {
+	if r.perTag {
+		return tag
+	}
+	return ""
+}
+
+func (r *flightRecorder) reset() {
+	r.buffers = map[string]*list.Element{}
+	r.lru.Init()
+}
+
+// state returns if the recorder is enabled and if the level is a trigger
+func (r *flightRecorder) state(level LogLevel) (enabled, trigger bool) {
+	r.Lock()
+	defer r.Unlock()
+	return r.enabled, atLeast(level, r.trigger)
+}
+
+// store adds a record to the buffer of its tag
+func (r *flightRecorder) store(rec flightRecord) {
+	r.Lock()
+	defer r.Unlock()
+
+	key := r.key(rec.instance.tag)
+	el := r.buffers[key]
+
+	if el == nil {
+		if r.lru.Len() >= r.maxTags {
+			oldest := r.lru.Back()
+			r.lru.Remove(oldest)
+			delete(r.buffers, oldest.Value.(*flightBuffer).key)
+		}
+		el = r.lru.PushFront(&flightBuffer{key: key, records: make([]flightRecord, r.size)})
+		r.buffers[key] = el
+	} else {
+		r.lru.MoveToFront(el)
+	}
+
+	el.Value.(*flightBuffer).push(rec)
+}
+
+// drain removes and returns the buffered records of the tag
+func (r *flightRecorder) drain(tag string) []flightRecord {
+	r.Lock()
+	defer r.Unlock()
+
+	key := r.key(tag)
+	el := r.buffers[key]
+	if el == nil {
+		return nil
+	}
+
+	r.lru.Remove(el)
+	delete(r.buffers, key)
+	return el.Value.(*flightBuffer).drain()
+}
+
+// record captures a log call in the flight recorder, even when its level is disabled. The message is only formatted when stored.
+// If the level is a trigger and enabled, the buffered records of the same tag are written out first
+func (i *slogInstance) record(level LogLevel, str interface{}, v []interface{}) {
+	enabled, trigger := recorder.state(level)
+	if !enabled {
+		return
+	}
+
+	if trigger {
+		if level != FATAL && level != PANIC && !enabledLevels[level] {
+			return // Keep the records until a trigger is written
+		}
+
+		for _, r := range recorder.drain(i.tag) {
+			r.instance.replayInstance(r).log(printfFormat("%s"), r.level, r.msg)
+		}
+		return
+	}
+
+	rec := flightRecord{
+		instance: i,
+		level:    level,
+		msg:      message(str, v...),
+		err:      findError(str, v),
+		time:     clock.Now(),
+	}
+
+	rec.caller, _ = i.caller(level)
+	recorder.store(rec)
+}
+
+func (i *slogInstance) replayInstance(r flightRecord) *slogInstance {
+	fields := map[string]interface{}{replayedField: true}
+	for k, v := range i.fields {
+		fields[k] = v
+	}
+
+	i2 := i.clone()
+	i2.fields = fields
+	i2.replayTime = r.time
+	i2.replayCaller = r.caller
+	if r.err != nil {
+		i2 = i2.withError(r.err)
+	}
+	return i2
+}
+
+// SetFlightRecorder globally enables the in-memory flight recorder. When enabled, records of every level (including disabled ones) are kept and replayed before a trigger level message of the same tag
+func SetFlightRecorder(enabled bool) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.enabled = enabled
+	recorder.reset()
+}
+
+// SetFlightRecorderSize sets how many records are kept by the flight recorder for each tag. Discards the records currently buffered
+func SetFlightRecorderSize(size int) {
+	if size < 1 {
+		size = 1
+	}
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.size = size
+	recorder.reset()
+}
+
+// SetFlightRecorderTrigger sets the minimum level that causes the flight recorder to replay its buffered records. Defaults to ERROR
+func SetFlightRecorderTrigger(level LogLevel) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.trigger = level
+}
+
+// SetFlightRecorderPerTag sets if the flight recorder keeps one buffer per tag (default) or a single global buffer. Discards the records currently buffered
+func SetFlightRecorderPerTag(enabled bool) {
+	recorder.Lock()
+	defer recorder.Unlock()
+	recorder.perTag = enabled
+	recorder.reset()
+}
+
+// FlightRecorderEnabled returns if the flight recorder is currently enabled
+func FlightRecorderEnabled() bool {
+	recorder.Lock()
+	defer recorder.Unlock()
+	return recorder.enabled
+}
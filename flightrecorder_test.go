@@ -0,0 +1,160 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFlightRecorder(t *testing.T) {
+	defer func() {
+		SetFlightRecorder(false)
+		SetFlightRecorderSize(100)
+		SetFlightRecorderTrigger(ERROR)
+		SetFieldRepresentation(JSONFields)
+		UnsetTestMode()
+	}()
+
+	SetFieldRepresentation(JSONFields)
+	UnsetTestMode()
+	SetDebug(false)
+	SetFlightRecorder(true)
+	SetFlightRecorderSize(2)
+
+	buff := bytes.NewBufferString("")
+	i := Scope("FlightRecorder").WithCustomWriter(buff)
+	req1 := i.Tag("REQ1")
+	req2 := i.Tag("REQ2")
+
+	req1.Debug("first debug")
+	req1.Debug("second debug")
+	req2.Debug("other request")
+	req1.Debug("third debug")
+
+	if buff.Len() != 0 {
+		t.Fatalf("Expected no output before trigger, got %q", buff.String())
+	}
+
+	req1.Error("request failed")
+
+	o := buff.String()
+	lines := strings.Split(strings.TrimSpace(o), LineBreak)
+
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines (2 replayed + error) got %d: %q", len(lines), o)
+	}
+
+	if !strings.Contains(lines[0], "second debug") || !strings.Contains(lines[1], "third debug") {
+		t.Errorf("Expected replayed lines in order, got %q", o)
+	}
+
+	if !strings.Contains(lines[0], `"replayed":true`) {
+		t.Errorf("Expected replayed field in %q", lines[0])
+	}
+
+	if !strings.Contains(lines[2], "request failed") || strings.Contains(lines[2], "replayed") {
+		t.Errorf("Expected trigger line not marked as replayed, got %q", lines[2])
+	}
+
+	if strings.Contains(o, "first debug") || strings.Contains(o, "other request") {
+		t.Errorf("Expected only the latest records of the same tag, got %q", o)
+	}
+
+	// Buffer is drained after replay
+	buff.Reset()
+	req1.Error("second failure")
+	if strings.Count(buff.String(), LineBreak) != 1 {
+		t.Errorf("Expected a single line after drain, got %q", buff.String())
+	}
+}
+
+func TestFlightRecorderGlobal(t *testing.T) {
+	defer func() {
+		SetFlightRecorder(false)
+		SetFlightRecorderPerTag(true)
+		SetFlightRecorderTrigger(ERROR)
+		UnsetTestMode()
+	}()
+
+	UnsetTestMode()
+	SetDebug(false)
+	SetFlightRecorder(true)
+	SetFlightRecorderPerTag(false)
+	SetFlightRecorderTrigger(WARN)
+
+	buff := bytes.NewBufferString("")
+	i := Scope("FlightRecorder").WithCustomWriter(buff)
+
+	i.Tag("A").Debug("debug from A")
+	i.Tag("B").Warn("warn from B")
+
+	o := buff.String()
+	if !strings.Contains(o, "debug from A") {
+		t.Errorf("Expected global buffer replay on WARN, got %q", o)
+	}
+}
+
+func TestFlightRecorderDisabled(t *testing.T) {
+	defer UnsetTestMode()
+
+	UnsetTestMode()
+	SetDebug(false)
+	buff := bytes.NewBufferString("")
+	i := Scope("FlightRecorder").WithCustomWriter(buff)
+
+	i.Debug("hidden")
+	i.Error("failure")
+
+	if strings.Contains(buff.String(), "hidden") {
+		t.Errorf("Expected no replay with flight recorder disabled, got %q", buff.String())
+	}
+}
+
+func TestFlightRecorderFormatsWhenRecorded(t *testing.T) {
+	defer func() {
+		SetFlightRecorder(false)
+		UnsetTestMode()
+	}()
+
+	UnsetTestMode()
+	SetDebug(false)
+	SetFlightRecorder(true)
+
+	buff := bytes.NewBufferString("")
+	i := Scope("FlightRecorder").WithCustomWriter(buff)
+
+	state := map[string]int{"retries": 0}
+	i.Debug("state: %v", state)
+	state["retries"] = 3
+	i.Error("failed after %d retries", state["retries"])
+
+	lines := strings.Split(strings.TrimSpace(buff.String()), LineBreak)
+	if len(lines) != 2 || !strings.Contains(lines[0], "state: map[retries:0]") {
+		t.Errorf("Expected state at the time of the debug line got %q", lines)
+	}
+}
+
+func TestFlightRecorderDisabledTrigger(t *testing.T) {
+	defer func() {
+		SetFlightRecorder(false)
+		UnsetTestMode()
+	}()
+
+	UnsetTestMode()
+	SetDebug(false)
+	SetError(false)
+	SetFlightRecorder(true)
+
+	buff := bytes.NewBufferString("")
+	i := Scope("FlightRecorder").WithCustomWriter(buff)
+
+	i.Debug("context")
+	i.Error("hidden failure")
+	SetError(true)
+	i.Error("failure")
+
+	lines := strings.Split(strings.TrimSpace(buff.String()), LineBreak)
+	if len(lines) != 2 || !strings.Contains(lines[0], "context") {
+		t.Errorf("Expected records kept until a trigger is written, got %q", lines)
+	}
+}
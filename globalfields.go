@@ -0,0 +1,142 @@
+package slog
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// FieldProvider returns fields to be added to the global fields, like HostnameFields or BuildInfoFields
+type FieldProvider func() map[string]interface{}
+
+// kubernetesEnv are the environment variables usually set from the Kubernetes downward API, and their fields
+var kubernetesEnv = map[string]string{
+	"POD_NAME":        "k8sPod",
+	"POD_NAMESPACE":   "k8sNamespace",
+	"POD_IP":          "k8sPodIP",
+	"NODE_NAME":       "k8sNode",
+	"SERVICE_ACCOUNT": "k8sServiceAccount",
+}
+
+var globalFields map[string]interface{}
+var globalFieldsLock sync.RWMutex
+
+// SetGlobalFields sets the fields logged by all instances, including the package level functions. Instance fields with the same keys take precedence.
+// The fields of the providers are added, without replacing the specified fields. Nil fields and no providers clear the global fields
+func SetGlobalFields(fields map[string]interface{}, providers ...FieldProvider) {
+	global := make(map[string]interface{}, len(fields))
+	for _, provider := range providers {
+		for k, v := range provider() {
+			global[k] = v
+		}
+	}
+
+	for k, v := range fields {
+		global[k] = v
+	}
+
+	globalFieldsLock.Lock()
+	defer globalFieldsLock.Unlock()
+	globalFields = global
+}
+
+// GlobalFields returns a copy of the fields logged by all instances
+func GlobalFields() map[string]interface{} {
+	globalFieldsLock.RLock()
+	defer globalFieldsLock.RUnlock()
+
+	fields := make(map[string]interface{}, len(globalFields))
+	for k, v := range globalFields {
+		fields[k] = v
+	}
+	return fields
+}
+
+// ServiceFields returns the service field, with the program name
+func ServiceFields() map[string]interface{} {
+	return map[string]interface{}{"service": strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")}
+}
+
+// HostnameFields returns the hostname field
+func HostnameFields() map[string]interface{} {
+	host, err := os.Hostname()
+	if err != nil {
+		return nil
+	}
+	return map[string]interface{}{"hostname": host}
+}
+
+// PidFields returns the pid field, with the process id
+func PidFields() map[string]interface{} {
+	return map[string]interface{}{"pid": os.Getpid()}
+}
+
+// GoVersionFields returns the goVersion field, with the Go version the program was built with
+func GoVersionFields() map[string]interface{} {
+	return map[string]interface{}{"goVersion": runtime.Version()}
+}
+
+// BuildInfoFields returns the module and version fields, from the main module, and the vcsRevision, vcsTime and vcsModified fields,
+// from the version control information stamped by go build. Missing information is left out
+func BuildInfoFields() map[string]interface{} {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+
+	fields := map[string]interface{}{}
+	if info.Main.Path != "" {
+		fields["module"] = info.Main.Path
+	}
+
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		fields["version"] = info.Main.Version
+	}
+
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			fields["vcsRevision"] = s.Value
+		case "vcs.time":
+			fields["vcsTime"] = s.Value
+		case "vcs.modified":
+			fields["vcsModified"] = s.Value == "true"
+		}
+	}
+
+	return fields
+}
+
+// KubernetesFields returns the k8sPod, k8sNamespace, k8sPodIP, k8sNode and k8sServiceAccount fields, from the POD_NAME, POD_NAMESPACE, POD_IP,
+// NODE_NAME and SERVICE_ACCOUNT environment variables, usually set from the downward API. Unset variables are left out
+func KubernetesFields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	for env, field := range kubernetesEnv {
+		if v := os.Getenv(env); v != "" {
+			fields[field] = v
+		}
+	}
+	return fields
+}
+
+// logFields returns the fields of a log line: the global fields and the instance fields, which take precedence
+func (i *slogInstance) logFields() map[string]interface{} {
+	globalFieldsLock.RLock()
+	defer globalFieldsLock.RUnlock()
+
+	if len(globalFields) == 0 {
+		return i.fields
+	}
+
+	fields := make(map[string]interface{}, len(globalFields)+len(i.fields))
+	for k, v := range globalFields {
+		fields[k] = v
+	}
+	for k, v := range i.fields {
+		fields[k] = v
+	}
+	return fields
+}
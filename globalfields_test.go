@@ -0,0 +1,88 @@
+package slog
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSetGlobalFields(t *testing.T) {
+	UnsetTestMode()
+	SetLogFormat(JSON)
+	defer SetLogFormat(PIPE)
+	defer SetGlobalFields(nil)
+
+	env := func() map[string]interface{} {
+		return map[string]interface{}{"env": "provided", "region": "sa-east-1"}
+	}
+	SetGlobalFields(map[string]interface{}{"service": "api", "env": "prod"}, env, PidFields)
+
+	buff := bytes.NewBufferString("")
+	SetDefaultOutput(buff)
+	defer SetDefaultOutput(os.Stdout)
+
+	Info("global instance")
+	Scope("Child").WithFields(map[string]interface{}{"service": "worker"}).Info("child instance")
+
+	entries := jsonEntries(t, buff)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 lines got %v", entries)
+	}
+
+	expected := []map[string]interface{}{
+		{"service": "api", "env": "prod", "region": "sa-east-1", "pid": float64(os.Getpid())},
+		{"service": "worker", "env": "prod", "region": "sa-east-1", "pid": float64(os.Getpid())},
+	}
+	for n, fields := range expected {
+		for k, v := range fields {
+			if entries[n][k] != v {
+				t.Errorf("Expected %s=%v in line %d got %v", k, v, n, entries[n])
+			}
+		}
+	}
+
+	fields := GlobalFields()
+	fields["service"] = "changed"
+	if GlobalFields()["service"] != "api" {
+		t.Errorf("Expected GlobalFields to return a copy")
+	}
+
+	SetGlobalFields(nil)
+	buff.Reset()
+	SetLogFormat(PIPE)
+	Info("no fields")
+	if !strings.HasSuffix(strings.TrimSpace(buff.String()), "| {}") {
+		t.Errorf("Expected no fields after clearing got %q", buff.String())
+	}
+}
+
+func TestFieldProviders(t *testing.T) {
+	if host, err := os.Hostname(); err == nil && HostnameFields()["hostname"] != host {
+		t.Errorf("Expected hostname %s got %v", host, HostnameFields())
+	}
+
+	if GoVersionFields()["goVersion"] != runtime.Version() {
+		t.Errorf("Expected Go version got %v", GoVersionFields())
+	}
+
+	if ServiceFields()["service"] == "" {
+		t.Errorf("Expected service name")
+	}
+
+	if fields := BuildInfoFields(); fields["module"] == nil {
+		t.Errorf("Expected module field got %v", fields)
+	}
+
+	t.Setenv("POD_NAME", "api-7d9f")
+	t.Setenv("POD_NAMESPACE", "payments")
+	t.Setenv("NODE_NAME", "")
+	fields := KubernetesFields()
+	if fields["k8sPod"] != "api-7d9f" || fields["k8sNamespace"] != "payments" {
+		t.Errorf("Expected pod and namespace fields got %v", fields)
+	}
+	if _, ok := fields["k8sNode"]; ok {
+		t.Errorf("Expected unset variables to be left out got %v", fields)
+	}
+}
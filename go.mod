@@ -1,6 +1,5 @@
 module github.com/quan-to/slog
 
-require (
-	github.com/bouk/monkey v1.0.1
-	github.com/logrusorgru/aurora v0.0.0-20190803045625-94edacc10f9b
-)
+go 1.22
+
+require github.com/logrusorgru/aurora v0.0.0-20190803045625-94edacc10f9b
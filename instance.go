@@ -4,8 +4,9 @@ import (
 	"fmt"
 	"github.com/logrusorgru/aurora"
 	"io"
+	stdslog "log/slog"
 	"os"
-	"reflect"
+	"runtime"
 	"runtime/debug"
 	"strings"
 	"time"
@@ -19,17 +20,20 @@ func SetScopeLength(length int) {
 }
 
 type slogInstance struct {
-	scope       []string
-	fields      map[string]interface{}
-	customOut   io.Writer
-	stackOffset int
-	tag         string
-	op          LogOperation
-}
-
-func (i *slogInstance) incStackOffset() *slogInstance {
-	i.stackOffset++
-	return i
+	scope      []string
+	fields     map[string]interface{}
+	customOut  io.Writer
+	callerSkip int
+	tag        string
+	op         LogOperation
+	buffer     *logBuffer
+	noColors   bool
+	noCaller   bool
+	handler    stdslog.Handler
+
+	// Set only on instances replaying flight recorder records
+	replayTime   time.Time
+	replayCaller runtime.Frame
 }
 
 func (i *slogInstance) buildText(str string, level LogLevel, v ...interface{}) string {
@@ -45,57 +49,149 @@ func (i *slogInstance) buildText(str string, level LogLevel, v ...interface{}) s
 }
 
 func (i *slogInstance) buildPipedLog(str string, level LogLevel, v ...interface{}) string {
-	logDate := aurora.Gray(7, formatTime(time.Now()))
-	levelColor := levelColors[level]
-	scope := padRight(strings.Join(i.scope, " > "), scopeLength)
-	stringifiedFields := "{}"
+	colors := i.colors()
+	levelColor := theme.levelColor(level)
+	columns := pipeColumns
 
-	if i.fields != nil {
-		stringifiedFields = buildFieldString(i.fields)
+	frame, hasCaller := i.caller(level)
+
+	sep := pipeSeparator
+	if colors {
+		sep = paintTrimmed(theme.Pipe, sep)
 	}
 
-	op := operationColors[i.op](padRight(string(i.op), maxOperationStringLength)).White()
-	tag := aurora.Gray(7, i.tag)
+	line := ""
+	lineLength := 0 // Without colors, to pad the message lines
+	first := true
 
-	logHead := logDate.String() + " " + pipeChar + " " + levelColor(aurora.Bold(level)).String() + " " + pipeChar + " " + op.String() + " " + pipeChar + " " + tag.String() + " " + pipeChar + " " + scope + " " + pipeChar + " "
+	for _, t := range pipeLayout {
+		c := columns[t.name]
+		if c.Hidden || (t.name == "caller" && !hasCaller) {
+			continue
+		}
 
-	if showLines {
-		cs := getCallerString(i.stackOffset)
-		logHead += cs + " " + pipeChar + " "
-	}
+		if !first {
+			line += sep
+			lineLength += len(pipeSeparator)
+		}
+		first = false
+
+		var text string
+		switch t.name {
+		case "time":
+			text = formatTime(i.now())
+		case "level":
+			text = string(level)
+		case "op":
+			text = string(i.op)
+		case "tag":
+			text = i.tag
+		case "scope":
+			text = strings.Join(i.scope, " > ")
+		case "caller":
+			text = formatCaller(frame)
+		case "msg":
+			text = fmt.Sprintf(asString(str), v...)
+		case "fields":
+			text = "{}"
+			if fields := i.logFields(); fields != nil {
+				text = buildFieldString(fields)
+			}
+		}
 
-	logTail := pipeChar + " " + stringifiedFields
-	logHeadLength := len(stripColors(logHead)) + 1
+		text = c.fit(text, defaultColumnWidth(t.name))
+		lineLength += len(t.prefix)
+		if t.name == "msg" {
+			text = addPadForLines(text, lineLength)
+		}
+		lineLength += len(text) + len(t.suffix)
+
+		if colors {
+			switch t.name {
+			case "time":
+				text = paint(theme.Date, text)
+			case "level":
+				text = levelColor(aurora.Bold(text).String())
+			case "op":
+				text = theme.operation(i.op, text)
+			case "tag":
+				text = theme.tag(text)
+			case "scope":
+				text = theme.scope(text)
+			case "msg":
+				text = levelColor(text)
+			}
+		}
 
-	baseString := fmt.Sprintf(asString(str), v...)
-	baseString = addPadForLines(baseString, logHeadLength)
+		line += t.prefix + text + t.suffix
+	}
 
-	return logHead + levelColor(baseString).String() + " " + logTail + LineBreak
+	return line + LineBreak
 }
 
 func (i *slogInstance) buildJSONLog(str string, level LogLevel, v ...interface{}) string {
-	jsonFields := i.fields
-
-	if jsonFields == nil {
-		jsonFields = make(map[string]interface{})
-	}
+	jsonFields := expandErrors(i.logFields())
 
-	jsonFields["time"] = formatTime(time.Now())
+	jsonFields["time"] = timeValue(i.now())
 	jsonFields["scope"] = strings.Join(i.scope, " - ")
 	jsonFields["op"] = i.op
 	jsonFields["tag"] = i.tag
 	jsonFields["level"] = getDescription(level)
 	jsonFields["msg"] = fmt.Sprintf(asString(str), v...)
 
-	if showLines {
-		jsonFields["lines"] = getCallerString(i.stackOffset)
+	if frame, ok := i.caller(level); ok {
+		if jsonCallerFields {
+			jsonFields["caller"] = callerFields(frame)
+		} else {
+			jsonFields["lines"] = formatCaller(frame)
+		}
+	}
+
+	if jsonGoroutine {
+		jsonFields["goroutine"] = goroutineID()
+	}
+
+	if jsonPid {
+		jsonFields["pid"] = os.Getpid()
 	}
 
 	return buildJSON(jsonFields) + LineBreak
 }
 
+// now returns the time to be displayed in the log line
+func (i *slogInstance) now() time.Time {
+	if !i.replayTime.IsZero() {
+		return i.replayTime
+	}
+	return clock.Now()
+}
+
+// output writes a built log line, holding it when the instance is buffered
+func (i *slogInstance) output(level LogLevel, p []byte) {
+	if i.buffer != nil && i.buffer.hold(i, level, p) {
+		return
+	}
+	i.write(level, p)
+}
+
 func (i *slogInstance) commonLog(str string, level LogLevel, v ...interface{}) {
-	_, _ = i.Write([]byte(i.buildText(str, level, v...)))
+	i.output(level, []byte(i.buildText(str, level, v...)))
+}
+
+// message returns the log message for str and its arguments, without any formatting
+func message(str interface{}, v ...interface{}) string {
+	switch ft := str.(type) {
+	case printfFormat:
+		return fmt.Sprintf(fitFormat(string(ft), len(v)), v...)
+	case printlnArgs:
+		return strings.TrimSpace(fmt.Sprintln(ft...))
+	case string:
+		if isFormat(ft) {
+			return fmt.Sprintf(fitFormat(ft, len(v)), v...)
+		}
+	}
+
+	return strings.TrimSpace(fmt.Sprintln(append([]interface{}{str}, v...)...))
 }
 
 func (i *slogInstance) argsOnlyLog(str interface{}, level LogLevel, v ...interface{}) {
@@ -107,17 +203,38 @@ func (i *slogInstance) argsOnlyLog(str interface{}, level LogLevel, v ...interfa
 		baseFormat += "%v "
 	}
 
-	_, _ = i.Write([]byte(i.buildText(baseFormat, level, args...)))
+	i.output(level, []byte(i.buildText(baseFormat, level, args...)))
 }
 
 func (i *slogInstance) log(str interface{}, level LogLevel, v ...interface{}) {
+	if err := findError(str, v); err != nil {
+		switch {
+		case !alreadyLogged(err) || level == FATAL || level == PANIC:
+			i = i.withError(err)
+		case errorOnly(str, v):
+			return
+		}
+	}
+
+	if i.handler != nil {
+		i.handle(str, level, v)
+		return
+	}
+
 	switch ft := str.(type) {
 	case string: // Use normal logging
-		if hasFormatData(ft) {
-			i.commonLog(ft, level, v...)
+		if isFormat(ft) {
+			i.commonLog(fitFormat(ft, len(v)), level, v...)
 		} else {
 			i.argsOnlyLog(str, level, v...)
 		}
+	case printfFormat: // Explicit format, from Infof and similar
+		i.commonLog(fitFormat(string(ft), len(v)), level, v...)
+	case printlnArgs: // Explicit args only, from Infoln and similar
+		if len(ft) == 0 {
+			ft = printlnArgs{""}
+		}
+		i.argsOnlyLog(ft[0], level, ft[1:]...)
 	default: // Args only, to enable slog.Info(a,b,c,d,e)
 		i.argsOnlyLog(str, level, v...)
 	}
@@ -129,17 +246,16 @@ func (i *slogInstance) Write(p []byte) (n int, err error) {
 		return i.customOut.Write(p)
 	}
 
-	fmt.Printf(string(p))
+	fmt.Print(string(p))
 	return len(p), nil
 }
 
 // LogNoFormat prints a log string without any ANSI formatting
 func (i *slogInstance) LogNoFormat(str interface{}, v ...interface{}) Instance {
 	if enabledLevels[INFO] {
-		i.stackOffset -= 2
-		txt := stripColors(i.buildText(asString(str), INFO, v...))
-		_, _ = i.Write([]byte(txt))
-		i.stackOffset += 2
+		i2 := i.clone()
+		i2.noColors = true
+		i.output(INFO, []byte(i2.buildText(asString(str), INFO, v...)))
 	}
 	return i
 }
@@ -147,6 +263,7 @@ func (i *slogInstance) LogNoFormat(str interface{}, v ...interface{}) Instance {
 // Log is equivalent of calling Info. It logs out a message in INFO level
 func (i *slogInstance) Log(str interface{}, v ...interface{}) Instance {
 	// Do not call i.Info, to not change the stack and break filename:line
+	i.record(INFO, str, v)
 	if enabledLevels[INFO] {
 		i.log(str, INFO, v...)
 	}
@@ -155,6 +272,7 @@ func (i *slogInstance) Log(str interface{}, v ...interface{}) Instance {
 
 // Info logs out a message in INFO level
 func (i *slogInstance) Info(str interface{}, v ...interface{}) Instance {
+	i.record(INFO, str, v)
 	if enabledLevels[INFO] {
 		i.log(str, INFO, v...)
 	}
@@ -163,14 +281,43 @@ func (i *slogInstance) Info(str interface{}, v ...interface{}) Instance {
 
 // Debug logs out a message in DEBUG level
 func (i *slogInstance) Debug(str interface{}, v ...interface{}) Instance {
+	i.record(DEBUG, str, v)
 	if enabledLevels[DEBUG] {
 		i.log(str, DEBUG, v...)
 	}
 	return i
 }
 
+// Trace logs out a message in TRACE level
+func (i *slogInstance) Trace(str interface{}, v ...interface{}) Instance {
+	i.record(TRACE, str, v)
+	if enabledLevels[TRACE] {
+		i.log(str, TRACE, v...)
+	}
+	return i
+}
+
+// Notice logs out a message in NOTICE level
+func (i *slogInstance) Notice(str interface{}, v ...interface{}) Instance {
+	i.record(NOTICE, str, v)
+	if enabledLevels[NOTICE] {
+		i.log(str, NOTICE, v...)
+	}
+	return i
+}
+
+// LogAt logs out a message in the specified level. FATAL and PANIC levels don't close the program or panic
+func (i *slogInstance) LogAt(level LogLevel, str interface{}, v ...interface{}) Instance {
+	i.record(level, str, v)
+	if enabledLevels[level] {
+		i.log(str, level, v...)
+	}
+	return i
+}
+
 // Warn logs out a message in WARN level
 func (i *slogInstance) Warn(str interface{}, v ...interface{}) Instance {
+	i.record(WARN, str, v)
 	if enabledLevels[WARN] {
 		i.log(str, WARN, v...)
 	}
@@ -179,33 +326,31 @@ func (i *slogInstance) Warn(str interface{}, v ...interface{}) Instance {
 
 // Error logs out a message in ERROR level
 func (i *slogInstance) Error(str interface{}, v ...interface{}) Instance {
+	i.record(ERROR, str, v)
 	if enabledLevels[ERROR] {
 		i.log(str, ERROR, v...)
 	}
 	return i
 }
 
-// Fatal logs out a message in ERROR level and closes the program
+// Fatal logs out a message in FATAL level, runs the exit hooks and closes the program
 func (i *slogInstance) Fatal(str interface{}, v ...interface{}) {
-	varargs := v
-	if len(varargs) == 1 && reflect.TypeOf(v[0]) == reflect.TypeOf([]interface{}{}) {
-		varargs = v[0].([]interface{})
-	}
-
-	var msg string
-	if varargs == nil || len(varargs) == 0 {
-		msg = asString(str)
-	} else {
-		msg = fmt.Sprintf(asString(str), varargs...)
-	}
-
-	i.log(msg, FATAL)
+	i.record(FATAL, str, v)
+	i.log(str, FATAL, v...)
 
 	stack := string(debug.Stack())
 
 	i.log(stack, FATAL)
 
-	os.Exit(1)
+	exit()
+}
+
+// Panic logs out a message in PANIC level and panics with the message
+func (i *slogInstance) Panic(str interface{}, v ...interface{}) {
+	i.record(PANIC, str, v)
+	i.log(str, PANIC, v...)
+
+	panic(message(str, v...))
 }
 
 // WithFields returns a new instance with the parent fields plus the current fields. If key collision happens, the value specified in fields argument will be used.
@@ -259,13 +404,30 @@ func (i *slogInstance) Operation(op LogOperation) Instance {
 	return i2
 }
 
+// WithCallerSkip returns a new instance that skips n more stack frames when looking for the caller of the log calls
+func (i *slogInstance) WithCallerSkip(n int) Instance {
+	i2 := i.clone()
+	i2.callerSkip += n
+	return i2
+}
+
+// Op returns a new instance with the specified operation. Same as Operation(LogOperation(name))
+func (i *slogInstance) Op(name string) Instance {
+	i2 := i.clone()
+	i2.op = LogOperation(name)
+	return i2
+}
+
 func (i *slogInstance) clone() *slogInstance {
 	return &slogInstance{
-		fields:      i.fields,
-		scope:       i.scope,
-		customOut:   i.customOut,
-		stackOffset: i.stackOffset,
-		op:          i.op,
-		tag:         i.tag,
+		fields:     i.fields,
+		scope:      i.scope,
+		customOut:  i.customOut,
+		callerSkip: i.callerSkip,
+		op:         i.op,
+		tag:        i.tag,
+		buffer:     i.buffer,
+		noCaller:   i.noCaller,
+		handler:    i.handler,
 	}
 }
@@ -0,0 +1,110 @@
+package slog
+
+// printfFormat is a message that is always handled as a printf format string
+type printfFormat string
+
+// printlnArgs is a message made only of arguments, without any format string
+type printlnArgs []interface{}
+
+// region --- TRACE Level Variants ---
+// Tracef logs out a message in TRACE level. The message is always handled as a printf format string
+func (i *slogInstance) Tracef(format string, v ...interface{}) Instance {
+	i.Trace(printfFormat(format), v...)
+	return i
+}
+
+// Traceln logs out a message in TRACE level. The arguments are joined by spaces, without any format string
+func (i *slogInstance) Traceln(v ...interface{}) Instance {
+	i.Trace(printlnArgs(v))
+	return i
+}
+
+// endregion
+// region --- NOTICE Level Variants ---
+// Noticef logs out a message in NOTICE level. The message is always handled as a printf format string
+func (i *slogInstance) Noticef(format string, v ...interface{}) Instance {
+	i.Notice(printfFormat(format), v...)
+	return i
+}
+
+// Noticeln logs out a message in NOTICE level. The arguments are joined by spaces, without any format string
+func (i *slogInstance) Noticeln(v ...interface{}) Instance {
+	i.Notice(printlnArgs(v))
+	return i
+}
+
+// endregion
+// region --- DEBUG Level Variants ---
+// Debugf logs out a message in DEBUG level. The message is always handled as a printf format string
+func (i *slogInstance) Debugf(format string, v ...interface{}) Instance {
+	i.Debug(printfFormat(format), v...)
+	return i
+}
+
+// Debugln logs out a message in DEBUG level. The arguments are joined by spaces, without any format string
+func (i *slogInstance) Debugln(v ...interface{}) Instance {
+	i.Debug(printlnArgs(v))
+	return i
+}
+
+// endregion
+// region --- INFO Level Variants ---
+// Infof logs out a message in INFO level. The message is always handled as a printf format string
+func (i *slogInstance) Infof(format string, v ...interface{}) Instance {
+	i.Info(printfFormat(format), v...)
+	return i
+}
+
+// Infoln logs out a message in INFO level. The arguments are joined by spaces, without any format string
+func (i *slogInstance) Infoln(v ...interface{}) Instance {
+	i.Info(printlnArgs(v))
+	return i
+}
+
+// endregion
+// region --- WARN Level Variants ---
+// Warnf logs out a message in WARN level. The message is always handled as a printf format string
+func (i *slogInstance) Warnf(format string, v ...interface{}) Instance {
+	i.Warn(printfFormat(format), v...)
+	return i
+}
+
+// Warnln logs out a message in WARN level. The arguments are joined by spaces, without any format string
+func (i *slogInstance) Warnln(v ...interface{}) Instance {
+	i.Warn(printlnArgs(v))
+	return i
+}
+
+// endregion
+// region --- ERROR Level Variants ---
+// Errorln logs out a message in ERROR level. The arguments are joined by spaces, without any format string
+func (i *slogInstance) Errorln(v ...interface{}) Instance {
+	i.Error(printlnArgs(v))
+	return i
+}
+
+// endregion
+// region --- FATAL Level Variants ---
+// Fatalf logs out a message in FATAL level, runs the exit hooks and closes the program. The message is always handled as a printf format string
+func (i *slogInstance) Fatalf(format string, v ...interface{}) {
+	i.Fatal(printfFormat(format), v...)
+}
+
+// Fatalln logs out a message in FATAL level, runs the exit hooks and closes the program. The arguments are joined by spaces, without any format string
+func (i *slogInstance) Fatalln(v ...interface{}) {
+	i.Fatal(printlnArgs(v))
+}
+
+// endregion
+// region --- PANIC Level Variants ---
+// Panicf logs out a message in PANIC level and panics with the message. The message is always handled as a printf format string
+func (i *slogInstance) Panicf(format string, v ...interface{}) {
+	i.Panic(printfFormat(format), v...)
+}
+
+// Panicln logs out a message in PANIC level and panics with the message. The arguments are joined by spaces, without any format string
+func (i *slogInstance) Panicln(v ...interface{}) {
+	i.Panic(printlnArgs(v))
+}
+
+// endregion
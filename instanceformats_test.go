@@ -0,0 +1,61 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFormatVariants(t *testing.T) {
+	UnsetTestMode()
+	SetFieldRepresentation(NoFields)
+	defer SetFieldRepresentation(JSONFields)
+
+	buff := bytes.NewBufferString("")
+	i := Scope("Variants").WithCustomWriter(buff)
+
+	testCases := []struct {
+		name     string
+		log      func()
+		expected string
+	}{
+		{"Info without args", func() { i.Info("100% done") }, "100% done"},
+		{"Info escaped percent", func() { i.Info("100%% done") }, "100% done"},
+		{"Info format without args", func() { i.Info("%d items") }, "%d items"},
+		{"Info lone percent", func() { i.Info("100% done for %s", "huebr") }, "100% done for huebr"},
+		{"Infof", func() { i.Infof("%s=%d", "a", 1) }, "a=1"},
+		{"Infof extra args", func() { i.Infof("values:", 1, 2) }, "values: 1 2"},
+		{"Infoln", func() { i.Infoln("%s", 100, "%") }, "%s 100 %"},
+		{"Debugf", func() { i.Debugf("%05d", 1) }, "00001"},
+		{"Debugln", func() { i.Debugln("a", "b") }, "a b"},
+		{"Warnf", func() { i.Warnf("%v%%", 10) }, "10%"},
+		{"Warnln", func() { i.Warnln("a%d") }, "a%d"},
+		{"Errorln", func() { i.Errorln("failed", 42) }, "failed 42"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buff.Reset()
+			tc.log()
+			if !strings.Contains(buff.String(), tc.expected) {
+				t.Errorf("Expected %q in output: %q", tc.expected, buff.String())
+			}
+			if strings.Contains(buff.String(), "%!") {
+				t.Errorf("Unexpected format error in output: %q", buff.String())
+			}
+		})
+	}
+}
+
+func TestPanicf(t *testing.T) {
+	buff := bytes.NewBufferString("")
+	i := Scope("Variants").WithCustomWriter(buff)
+
+	defer func() {
+		if r := recover(); r != "a=1" {
+			t.Errorf("Got %v want %q", r, "a=1")
+		}
+	}()
+
+	i.Panicf("a=%d", 1)
+}
@@ -0,0 +1,239 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// transfer holds the state of a wrapped reader or writer
+type transfer struct {
+	mu       sync.Mutex
+	inst     Instance
+	name     string
+	verb     string
+	action   string
+	closer   io.Closer
+	start    time.Time
+	last     time.Time
+	total    int64
+	progress time.Duration
+	hexdump  int
+	dump     []byte
+	closed   bool
+}
+
+// newTransfer logs the opening of v. verb and action describe the transfer in the messages, like read and reading
+func newTransfer(inst Instance, name, verb, action string, v interface{}) *transfer {
+	t := &transfer{
+		inst:   inst,
+		name:   name,
+		verb:   verb,
+		action: action,
+		start:  clock.Now(),
+	}
+	t.last = t.start
+	t.closer, _ = v.(io.Closer)
+
+	inst.IO("Opened %s", name)
+	return t
+}
+
+// count accounts n transferred bytes of p, logging the progress, the hexdump and errors other than io.EOF
+func (t *transfer) count(p []byte, n int, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.total += int64(n)
+
+	if t.hexdump > len(t.dump) {
+		t.dump = append(t.dump, p[:min(n, t.hexdump-len(t.dump))]...)
+		if len(t.dump) == t.hexdump {
+			t.logHexdump()
+		}
+	}
+
+	if err != nil && err != io.EOF {
+		t.inst.WithFields(t.fields(clock.Now())).ErrorIO("Error %s %s: %s", t.action, t.name, err)
+	}
+
+	if t.progress > 0 && n > 0 {
+		if now := clock.Now(); now.Sub(t.last) >= t.progress {
+			t.last = now
+			t.inst.WithFields(t.fields(now)).Await("%s: %s %s so far", t.name, formatBytes(t.total), t.verb)
+		}
+	}
+}
+
+func (t *transfer) logHexdump() {
+	if LevelEnabled(DEBUG) && len(t.dump) > 0 {
+		t.inst.DebugIO("First %d bytes of %s:\n%s", len(t.dump), t.name, bytes.TrimSuffix([]byte(hex.Dump(t.dump)), []byte("\n")))
+	}
+	t.hexdump = len(t.dump)
+}
+
+// fields returns the total bytes, duration and throughput of the transfer until now
+func (t *transfer) fields(now time.Time) map[string]interface{} {
+	elapsed := now.Sub(t.start)
+	return map[string]interface{}{
+		"bytes":      t.total,
+		"duration":   elapsed.String(),
+		"throughput": formatThroughput(t.total, elapsed),
+	}
+}
+
+func (t *transfer) close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+
+	if t.hexdump > len(t.dump) {
+		t.logHexdump()
+	}
+
+	var err error
+	if t.closer != nil {
+		err = t.closer.Close()
+	}
+
+	fields := t.fields(clock.Now())
+	if err != nil {
+		t.inst.WithFields(fields).ErrorIO("Error closing %s: %s", t.name, err)
+		return err
+	}
+
+	if t.verb == "" {
+		t.inst.WithFields(map[string]interface{}{"duration": fields["duration"]}).IO("Closed %s after %s", t.name, fields["duration"])
+		return nil
+	}
+
+	t.inst.WithFields(fields).IO("Closed %s: %s %s in %s (%s)", t.name, formatBytes(t.total), t.verb, fields["duration"], fields["throughput"])
+	return nil
+}
+
+func (t *transfer) setProgress(interval time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.progress = interval
+}
+
+func (t *transfer) setHexdump(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.hexdump = n
+}
+
+// Reader is an io.ReadCloser that logs the reads of a reader
+type Reader struct {
+	r io.Reader
+	t *transfer
+}
+
+// WrapReader returns a reader that logs an IO line when opened and closed, with the total bytes read, throughput and duration.
+// Errors other than io.EOF are logged with ErrorIO. Close closes r if it is an io.Closer
+func WrapReader(inst Instance, name string, r io.Reader) *Reader {
+	return &Reader{r: r, t: newTransfer(inst, name, "read", "reading", r)}
+}
+
+// SetProgress sets the interval of the AWAIT lines logging the progress of long reads. Zero disables them (default)
+func (r *Reader) SetProgress(interval time.Duration) *Reader {
+	r.t.setProgress(interval)
+	return r
+}
+
+// SetHexdump sets how many of the first bytes read are logged as a hexdump, in DEBUG level. Zero disables it (default)
+func (r *Reader) SetHexdump(n int) *Reader {
+	r.t.setHexdump(n)
+	return r
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.t.count(p, n, err)
+	return n, err
+}
+
+// Close logs the totals and closes the wrapped reader if it is an io.Closer. Only the first call has effect
+func (r *Reader) Close() error {
+	return r.t.close()
+}
+
+// Writer is an io.WriteCloser that logs the writes of a writer
+type Writer struct {
+	w io.Writer
+	t *transfer
+}
+
+// WrapWriter returns a writer that logs an IO line when opened and closed, with the total bytes written, throughput and duration.
+// Errors are logged with ErrorIO. Close closes w if it is an io.Closer
+func WrapWriter(inst Instance, name string, w io.Writer) *Writer {
+	return &Writer{w: w, t: newTransfer(inst, name, "written", "writing", w)}
+}
+
+// SetProgress sets the interval of the AWAIT lines logging the progress of long writes. Zero disables them (default)
+func (w *Writer) SetProgress(interval time.Duration) *Writer {
+	w.t.setProgress(interval)
+	return w
+}
+
+// SetHexdump sets how many of the first bytes written are logged as a hexdump, in DEBUG level. Zero disables it (default)
+func (w *Writer) SetHexdump(n int) *Writer {
+	w.t.setHexdump(n)
+	return w
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.t.count(p, n, err)
+	return n, err
+}
+
+// Close logs the totals and closes the wrapped writer if it is an io.Closer. Only the first call has effect
+func (w *Writer) Close() error {
+	return w.t.close()
+}
+
+// Closer is an io.Closer that logs when a resource is opened and closed
+type Closer struct {
+	t *transfer
+}
+
+// WrapCloser returns a closer that logs an IO line when opened and closed, with the duration. Errors are logged with ErrorIO
+func WrapCloser(inst Instance, name string, c io.Closer) *Closer {
+	return &Closer{t: newTransfer(inst, name, "", "closing", c)}
+}
+
+// Close logs the duration and closes the wrapped closer. Only the first call has effect
+func (c *Closer) Close() error {
+	return c.t.close()
+}
+
+// formatBytes formats a byte count in binary units, like 1.5 MiB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// formatThroughput formats the bytes per second of a transfer, like 1.5 MiB/s
+func formatThroughput(n int64, elapsed time.Duration) string {
+	if elapsed <= 0 {
+		return "- B/s"
+	}
+	return formatBytes(int64(float64(n)/elapsed.Seconds())) + "/s"
+}
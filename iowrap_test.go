@@ -0,0 +1,173 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// manualClock is a clock that only advances when told to
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+func (c *manualClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+type closeRecorder struct {
+	closed bool
+	err    error
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return c.err
+}
+
+func jsonEntries(t *testing.T, buff fmt.Stringer) []map[string]interface{} {
+	t.Helper()
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buff.String()), LineBreak) {
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Invalid JSON line %q: %s", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestWrapReader(t *testing.T) {
+	UnsetTestMode()
+	SetLogFormat(JSON)
+	defer SetLogFormat(PIPE)
+	c := &manualClock{now: time.Unix(0, 0)}
+	SetClock(c)
+	defer SetClock(nil)
+	debug := LevelEnabled(DEBUG)
+	SetDebug(true)
+	defer SetDebug(debug)
+
+	buff := bytes.NewBufferString("")
+	r := WrapReader(Scope("Copy").WithCustomWriter(buff), "data.bin", io.NopCloser(strings.NewReader(strings.Repeat("x", 3000)))).
+		SetProgress(2 * time.Second).
+		SetHexdump(4)
+
+	p := make([]byte, 1024)
+	for _, step := range []time.Duration{0, 3 * time.Second, time.Second, 0} {
+		c.advance(step)
+		_, _ = r.Read(p)
+	}
+
+	c.advance(time.Second)
+	if err := r.Close(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	_ = r.Close()
+
+	entries := jsonEntries(t, buff)
+	var ops, msgs []string
+	for _, e := range entries {
+		ops = append(ops, e["op"].(string))
+		msgs = append(msgs, e["msg"].(string))
+	}
+
+	expectedOps := []string{"IO", "IO", "AWAIT", "IO"}
+	if strings.Join(ops, ",") != strings.Join(expectedOps, ",") {
+		t.Fatalf("Expected operations %v got %v: %q", expectedOps, ops, msgs)
+	}
+
+	if msgs[0] != "Opened data.bin" {
+		t.Errorf("Expected open line got %q", msgs[0])
+	}
+
+	if entries[1]["level"] != "debug" || !strings.HasPrefix(msgs[1], "First 4 bytes of data.bin:\n00000000  78 78 78 78 ") {
+		t.Errorf("Expected hexdump got %v", entries[1])
+	}
+
+	if msgs[2] != "data.bin: 2.0 KiB read so far" || entries[2]["bytes"] != float64(2048) {
+		t.Errorf("Expected progress line got %v", entries[2])
+	}
+
+	if msgs[3] != "Closed data.bin: 2.9 KiB read in 5s (600 B/s)" || entries[3]["bytes"] != float64(3000) {
+		t.Errorf("Expected close line with totals got %v", entries[3])
+	}
+}
+
+func TestWrapWriter(t *testing.T) {
+	UnsetTestMode()
+	SetLogFormat(JSON)
+	defer SetLogFormat(PIPE)
+
+	buff := bytes.NewBufferString("")
+	w := WrapWriter(Scope("Upload").WithCustomWriter(buff), "socket", failingWriter{})
+	if _, err := w.Write([]byte("hello")); err == nil {
+		t.Fatalf("Expected error")
+	}
+	_ = w.Close()
+
+	entries := jsonEntries(t, buff)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 lines got %v", entries)
+	}
+
+	if entries[1]["level"] != "error" || entries[1]["op"] != "IO" || entries[1]["msg"] != "Error writing socket: disk full" {
+		t.Errorf("Expected error line got %v", entries[1])
+	}
+
+	if entries[2]["msg"] != "Closed socket: 0 B written in "+entries[2]["duration"].(string)+" ("+entries[2]["throughput"].(string)+")" {
+		t.Errorf("Expected close line got %v", entries[2])
+	}
+}
+
+func TestWrapCloser(t *testing.T) {
+	UnsetTestMode()
+
+	buff := bytes.NewBufferString("")
+	c := &closeRecorder{err: errors.New("broken pipe")}
+	if err := WrapCloser(Scope("Conn").WithCustomWriter(buff), "conn", c).Close(); err == nil || !c.closed {
+		t.Fatalf("Expected wrapped closer to be closed with error")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buff.String()), LineBreak)
+	if len(lines) != 2 || !strings.Contains(lines[0], "Opened conn") || !strings.Contains(lines[1], "Error closing conn: broken pipe") {
+		t.Errorf("Expected open and close error lines got %q", lines)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+		3 << 40:         "3.0 TiB",
+	}
+
+	for n, expected := range tests {
+		if got := formatBytes(n); got != expected {
+			t.Errorf("Expected %q for %d got %q", expected, n, got)
+		}
+	}
+
+	if got := formatThroughput(3<<20, 2*time.Second); got != "1.5 MiB/s" {
+		t.Errorf("Expected 1.5 MiB/s got %q", got)
+	}
+}
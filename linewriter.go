@@ -0,0 +1,131 @@
+package slog
+
+import (
+	"bytes"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// maxLineLength is the maximum size of a line held by a LineWriter. Longer lines are split
+const maxLineLength = 64 * 1024
+
+// linePrefixLevels are the level names parsed from the line prefixes, like [WARN] or ERROR:
+var linePrefixLevels = map[string]LogLevel{
+	"TRACE":   TRACE,
+	"DEBUG":   DEBUG,
+	"INFO":    INFO,
+	"NOTICE":  NOTICE,
+	"WARN":    WARN,
+	"WARNING": WARN,
+	"ERROR":   ERROR,
+	"ERR":     ERROR,
+	"FATAL":   FATAL,
+	"PANIC":   PANIC,
+}
+
+var linePrefixRgx = regexp.MustCompile(`^\s*(?:\[([A-Za-z]+)\]|([A-Za-z]+):)\s*`)
+
+// LineWriter is an io.WriteCloser that logs each line written to it through an instance.
+// Partial lines are held until completed, or until Close
+type LineWriter struct {
+	mu            sync.Mutex
+	inst          Instance
+	level         LogLevel
+	parsePrefixes bool
+	whole         bool
+	buf           []byte
+}
+
+// LineWriter returns a writer that logs each line written to it in the specified level and operation.
+// The lines don't show the caller, as the writes usually come from fmt, io.Copy or bufio instead of the code that produced the lines
+func (i *slogInstance) LineWriter(level LogLevel, op LogOperation) *LineWriter {
+	i2 := i.clone()
+	i2.op = op
+	i2.noCaller = true
+
+	return &LineWriter{
+		inst:  i2,
+		level: level,
+	}
+}
+
+// StdLogger returns a standard library logger that logs each message through the instance in the specified level
+func (i *slogInstance) StdLogger(level LogLevel) *log.Logger {
+	w := &LineWriter{
+		inst:  i,
+		level: level,
+		whole: true,
+	}
+	return log.New(w, "", 0)
+}
+
+// SetParsePrefixes sets if a level prefix in the lines, like [WARN], WARNING: or [error], sets the level of the line instead of the writer level.
+// The prefix is removed from the message
+func (w *LineWriter) SetParsePrefixes(enabled bool) *LineWriter {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.parsePrefixes = enabled
+	return w
+}
+
+// Write logs the complete lines in p, holding the last one if it is not complete
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.whole {
+		w.emit(string(bytes.TrimSuffix(p, []byte("\n"))))
+		return len(p), nil
+	}
+
+	w.buf = append(w.buf, p...)
+	for {
+		n := bytes.IndexByte(w.buf, '\n')
+		if n < 0 {
+			break
+		}
+		w.emit(string(w.buf[:n]))
+		w.buf = w.buf[n+1:]
+	}
+
+	for len(w.buf) >= maxLineLength {
+		w.emit(string(w.buf[:maxLineLength]))
+		w.buf = w.buf[maxLineLength:]
+	}
+
+	if len(w.buf) == 0 {
+		w.buf = nil
+	}
+
+	return len(p), nil
+}
+
+// Close logs the held partial line, if any
+func (w *LineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.emit(string(w.buf))
+		w.buf = nil
+	}
+	return nil
+}
+
+func (w *LineWriter) emit(line string) {
+	line = strings.TrimSuffix(line, "\r")
+	level := w.level
+
+	if w.parsePrefixes {
+		if m := linePrefixRgx.FindStringSubmatch(line); m != nil {
+			if l, ok := linePrefixLevels[strings.ToUpper(m[1]+m[2])]; ok {
+				level = l
+				line = line[len(m[0]):]
+			}
+		}
+	}
+
+	w.inst.LogAt(level, "%s", line)
+}
@@ -0,0 +1,125 @@
+package slog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLineWriter(t *testing.T) {
+	UnsetTestMode()
+	defer SetScopeLength(scopeLength)
+	SetScopeLength(24)
+
+	buff := bytes.NewBufferString("")
+	w := Scope("Lines").Tag("T1").WithCustomWriter(buff).LineWriter(WARN, IO)
+
+	_, _ = w.Write([]byte("first line\nsecond "))
+	_, _ = w.Write([]byte("line\r\nthird"))
+
+	o := stripColors(buff.String())
+	if !strings.Contains(o, "| W | IO    | T1 | Lines                    | first line |") || !strings.Contains(o, "| second line |") {
+		t.Errorf("Expected complete lines to be logged: %q", o)
+	}
+
+	if strings.Contains(o, "third") {
+		t.Errorf("Expected partial line to be held: %q", o)
+	}
+
+	_ = w.Close()
+	if !strings.Contains(stripColors(buff.String()), "| third |") {
+		t.Errorf("Expected partial line to be flushed on close: %q", buff.String())
+	}
+}
+
+func TestLineWriterPrefixes(t *testing.T) {
+	UnsetTestMode()
+	defer SetScopeLength(scopeLength)
+	SetScopeLength(24)
+
+	buff := bytes.NewBufferString("")
+	w := Scope("Lines").WithCustomWriter(buff).LineWriter(INFO, MSG).SetParsePrefixes(true)
+
+	_, _ = fmt.Fprintln(w, "[WARN] disk almost full")
+	_, _ = fmt.Fprintln(w, "error: cannot connect")
+	_, _ = fmt.Fprintln(w, "[unknown] kept as is")
+	_, _ = fmt.Fprintln(w, "no prefix")
+
+	lines := strings.Split(strings.TrimSpace(stripColors(buff.String())), LineBreak)
+	expected := []string{
+		"| W | MSG   | NONE | Lines                    | disk almost full |",
+		"| E | MSG   | NONE | Lines                    | cannot connect |",
+		"| I | MSG   | NONE | Lines                    | [unknown] kept as is |",
+		"| I | MSG   | NONE | Lines                    | no prefix |",
+	}
+
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines got %q", len(expected), lines)
+	}
+
+	for n, e := range expected {
+		if !strings.Contains(lines[n], e) {
+			t.Errorf("Expected %q in %q", e, lines[n])
+		}
+	}
+}
+
+func TestLineWriterLongLine(t *testing.T) {
+	UnsetTestMode()
+
+	buff := bytes.NewBufferString("")
+	w := Scope("Lines").WithCustomWriter(buff).LineWriter(INFO, MSG)
+
+	_, _ = w.Write(bytes.Repeat([]byte("a"), maxLineLength+10))
+	if c := strings.Count(buff.String(), LineBreak); c != 1 {
+		t.Errorf("Expected long line to be split, got %d lines", c)
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	UnsetTestMode()
+	SetShowLines(true)
+	defer SetShowLines(false)
+	defer SetScopeLength(scopeLength)
+	SetScopeLength(24)
+
+	buff := bytes.NewBufferString("")
+	l := Scope("Std").WithCustomWriter(buff).StdLogger(WARN)
+
+	l.Printf("multi\nline")
+	line := currentLine() - 1
+
+	o := stripColors(buff.String())
+	expected := fmt.Sprintf("| W | MSG   | NONE | Std                      | linewriter_test.go:%d | multi", line)
+	if !strings.Contains(o, expected) {
+		t.Errorf("Expected %q in output: %q", expected, o)
+	}
+
+	if strings.Count(o, "| W |") != 1 {
+		t.Errorf("Expected a single log line for the message: %q", o)
+	}
+}
+
+func TestLineWriterCaller(t *testing.T) {
+	UnsetTestMode()
+	SetShowLines(true)
+	defer SetShowLines(false)
+	defer SetScopeLength(scopeLength)
+	SetScopeLength(24)
+
+	buff := bytes.NewBufferString("")
+	w := Scope("Lines").WithCustomWriter(buff).LineWriter(INFO, MSG)
+
+	_, _ = fmt.Fprintln(w, "printed")
+	_, _ = io.Copy(w, strings.NewReader("copied\n"))
+
+	o := stripColors(buff.String())
+	for _, msg := range []string{"printed", "copied"} {
+		expected := fmt.Sprintf("| Lines                    | %s |", msg)
+		if !strings.Contains(o, expected) {
+			t.Errorf("Expected %q without caller in output: %q", expected, o)
+		}
+	}
+}
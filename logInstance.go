@@ -1,6 +1,10 @@
 package slog
 
-import "io"
+import (
+	"io"
+	"log"
+	stdslog "log/slog"
+)
 
 // Instance is a interface to a compatible SLog Logging Instance
 type Instance interface {
@@ -10,12 +14,22 @@ type Instance interface {
 	SubScope(string) Instance
 	// WithCustomWriter returns a new instance with the specified custom output
 	WithCustomWriter(io.Writer) Instance
+	// LineWriter returns a writer that logs each line written to it in the specified level and operation
+	LineWriter(level LogLevel, op LogOperation) *LineWriter
+	// StdLogger returns a standard library logger that logs each message through the instance in the specified level
+	StdLogger(level LogLevel) *log.Logger
+	// WithHandler returns a new instance that emits its log lines through the log/slog handler instead of its output
+	WithHandler(stdslog.Handler) Instance
 	// WithFields returns a new instance with the parent fields plus the current fields. If key collision happens, the value specified in fields argument will be used.
 	WithFields(map[string]interface{}) Instance
 	// Tag returns a new instance with the specified tag.
 	Tag(string) Instance
 	// Operation returns a new instance with the specified operation.
 	Operation(LogOperation) Instance
+	// Op returns a new instance with the specified operation. Same as Operation(LogOperation(name))
+	Op(string) Instance
+	// WithCallerSkip returns a new instance that skips n more stack frames when looking for the caller of the log calls
+	WithCallerSkip(n int) Instance
 
 	// LogNoFormat prints a log string without any ANSI formatting
 	LogNoFormat(interface{}, ...interface{}) Instance
@@ -24,12 +38,55 @@ type Instance interface {
 	Info(str interface{}, v ...interface{}) Instance
 	// Debug logs out a message in DEBUG level
 	Debug(str interface{}, v ...interface{}) Instance
+	// Trace logs out a message in TRACE level
+	Trace(str interface{}, v ...interface{}) Instance
+	// Notice logs out a message in NOTICE level
+	Notice(str interface{}, v ...interface{}) Instance
+	// LogAt logs out a message in the specified level. FATAL and PANIC levels don't close the program or panic
+	LogAt(level LogLevel, str interface{}, v ...interface{}) Instance
 	// Warn logs out a message in WARN level
 	Warn(str interface{}, v ...interface{}) Instance
 	// Error logs out a message in ERROR level
 	Error(str interface{}, v ...interface{}) Instance
-	// Fatal logs out a message in ERROR level and closes the program
+	// Errorf logs out a message in ERROR level and returns it as an error carrying the instance scope, tag and fields
+	Errorf(format string, v ...interface{}) error
+	// Fatal logs out a message in FATAL level, runs the exit hooks and closes the program
 	Fatal(str interface{}, v ...interface{})
+	// Panic logs out a message in PANIC level and panics with the message
+	Panic(str interface{}, v ...interface{})
+	// Recover recovers from a panic and logs it with the panic value and stack trace as fields. Should be called directly with defer: defer log.Recover()
+	Recover(mode ...RecoverMode)
+
+	// Tracef logs out a message in TRACE level. The message is always handled as a printf format string
+	Tracef(format string, v ...interface{}) Instance
+	// Traceln logs out a message in TRACE level. The arguments are joined by spaces, without any format string
+	Traceln(v ...interface{}) Instance
+	// Noticef logs out a message in NOTICE level. The message is always handled as a printf format string
+	Noticef(format string, v ...interface{}) Instance
+	// Noticeln logs out a message in NOTICE level. The arguments are joined by spaces, without any format string
+	Noticeln(v ...interface{}) Instance
+	// Debugf logs out a message in DEBUG level. The message is always handled as a printf format string
+	Debugf(format string, v ...interface{}) Instance
+	// Debugln logs out a message in DEBUG level. The arguments are joined by spaces, without any format string
+	Debugln(v ...interface{}) Instance
+	// Infof logs out a message in INFO level. The message is always handled as a printf format string
+	Infof(format string, v ...interface{}) Instance
+	// Infoln logs out a message in INFO level. The arguments are joined by spaces, without any format string
+	Infoln(v ...interface{}) Instance
+	// Warnf logs out a message in WARN level. The message is always handled as a printf format string
+	Warnf(format string, v ...interface{}) Instance
+	// Warnln logs out a message in WARN level. The arguments are joined by spaces, without any format string
+	Warnln(v ...interface{}) Instance
+	// Errorln logs out a message in ERROR level. The arguments are joined by spaces, without any format string
+	Errorln(v ...interface{}) Instance
+	// Fatalf logs out a message in FATAL level. The message is always handled as a printf format string
+	Fatalf(format string, v ...interface{})
+	// Fatalln logs out a message in FATAL level. The arguments are joined by spaces, without any format string
+	Fatalln(v ...interface{})
+	// Panicf logs out a message in PANIC level. The message is always handled as a printf format string
+	Panicf(format string, v ...interface{})
+	// Panicln logs out a message in PANIC level. The arguments are joined by spaces, without any format string
+	Panicln(v ...interface{})
 
 	// Note logs out a message in INFO level and with Operation NOTE. Returns an instance of operation NOTE
 	Note(interface{}, ...interface{}) Instance
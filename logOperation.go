@@ -1,6 +1,10 @@
 package slog
 
-import "github.com/logrusorgru/aurora"
+import (
+	"sync"
+
+	"github.com/logrusorgru/aurora"
+)
 
 type LogOperation string
 
@@ -10,6 +14,9 @@ const (
 	AWAIT              = "AWAIT"
 	DONE               = "DONE"
 	NOTE               = "NOTE"
+	RETRY              = "RETRY"
+	START              = "START"
+	STOP               = "STOP"
 )
 
 var operationColors = map[LogOperation]colorFunc{
@@ -18,16 +25,54 @@ var operationColors = map[LogOperation]colorFunc{
 	AWAIT: aurora.BgCyan,
 	DONE:  aurora.BgGreen,
 	NOTE:  aurora.BgBlack,
+	RETRY: aurora.BgYellow,
+	START: aurora.BgBlue,
+	STOP:  aurora.BgBlue,
 }
 
+var operationsLock sync.RWMutex
+
 var maxOperationStringLength = int(0)
 
 func init() {
-	// Compute max length between LogOperation strings
-	operations := []LogOperation{MSG, IO, AWAIT, DONE, NOTE}
-	for _, v := range operations {
+	computeMaxOperationStringLength()
+}
+
+// computeMaxOperationStringLength computes max length between LogOperation strings
+func computeMaxOperationStringLength() {
+	maxOperationStringLength = 0
+	for v := range operationColors {
 		if len(v) > maxOperationStringLength {
 			maxOperationStringLength = len(v)
 		}
 	}
 }
+
+// RegisterOperation registers a custom operation with its background color (like aurora.BgRed) and returns it. Replaces the color of an already registered operation
+func RegisterOperation(name string, color func(interface{}) aurora.Value) LogOperation {
+	operationsLock.Lock()
+	defer operationsLock.Unlock()
+
+	op := LogOperation(name)
+	operationColors[op] = color
+	computeMaxOperationStringLength()
+	return op
+}
+
+// operationColor returns the color of the operation. Operations that are not registered use the MSG color
+func operationColor(op LogOperation) colorFunc {
+	operationsLock.RLock()
+	defer operationsLock.RUnlock()
+
+	if c := operationColors[op]; c != nil {
+		return c
+	}
+	return operationColors[MSG]
+}
+
+// operationLength returns the length used to pad the operation column
+func operationLength() int {
+	operationsLock.RLock()
+	defer operationsLock.RUnlock()
+	return maxOperationStringLength
+}
@@ -0,0 +1,55 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/logrusorgru/aurora"
+)
+
+func TestUnregisteredOperation(t *testing.T) {
+	UnsetTestMode()
+	buff := bytes.NewBufferString("")
+	i := Scope("Operation").WithCustomWriter(buff)
+
+	i.Operation("DB").Info("Querying") // Should not crash
+	i.Op("CACHE").Info("Hit")
+
+	o := stripColors(buff.String())
+	if !strings.Contains(o, "| DB    |") || !strings.Contains(o, "| CACHE |") {
+		t.Errorf("Expected custom operations in output: %q", o)
+	}
+}
+
+func TestRegisterOperation(t *testing.T) {
+	UnsetTestMode()
+	defer func() {
+		delete(operationColors, "TRANSACTION")
+		computeMaxOperationStringLength()
+	}()
+
+	op := RegisterOperation("TRANSACTION", aurora.BgRed)
+	if op != LogOperation("TRANSACTION") {
+		t.Errorf("Got %q want %q.", op, "TRANSACTION")
+	}
+
+	if maxOperationStringLength != len("TRANSACTION") {
+		t.Errorf("Expected operation padding to be %d got %d", len("TRANSACTION"), maxOperationStringLength)
+	}
+
+	buff := bytes.NewBufferString("")
+	SetWriterColorMode(buff, ColorAlways)
+	i := Scope("Operation").WithCustomWriter(buff)
+	i.Operation(op).Info("Committing")
+	i.Operation(RETRY).Info("Retrying")
+
+	o := buff.String()
+	if !strings.Contains(o, aurora.BgRed(padRight("TRANSACTION", maxOperationStringLength)).White().String()) {
+		t.Errorf("Expected colored operation in output: %q", o)
+	}
+
+	if !strings.Contains(stripColors(o), "| RETRY       |") {
+		t.Errorf("Expected padded operation in output: %q", stripColors(o))
+	}
+}
@@ -18,24 +18,47 @@ const (
 	// FATAL represents an fatal message
 	FATAL = "F"
 
+	// PANIC represents an message logged right before a panic
+	PANIC = "P"
+
 	// DEBUG represents an debug message
 	DEBUG = "D"
+
+	// TRACE represents a very detailed debug message. Disabled by default
+	TRACE = "T"
+
+	// NOTICE represents a normal but significant information
+	NOTICE = "N"
 )
 
 var levelColors = map[LogLevel]colorFunc{
-	INFO:  aurora.Cyan,
-	WARN:  aurora.Yellow,
-	ERROR: aurora.Red,
-	DEBUG: aurora.Magenta,
-	FATAL: aurora.Red,
+	INFO:   aurora.Cyan,
+	WARN:   aurora.Yellow,
+	ERROR:  aurora.Red,
+	DEBUG:  aurora.Magenta,
+	FATAL:  aurora.Red,
+	PANIC:  aurora.BrightRed,
+	TRACE:  aurora.BrightBlack,
+	NOTICE: aurora.Green,
+}
+
+// getColor returns the color of the level. Levels that are not registered are white
+func getColor(level LogLevel) colorFunc {
+	if c := levelColors[level]; c != nil {
+		return c
+	}
+	return aurora.White
 }
 
 var levelDescription = map[LogLevel]string{
-	INFO:  "info",
-	WARN:  "warn",
-	ERROR: "error",
-	DEBUG: "debug",
-	FATAL: "fatal",
+	INFO:   "info",
+	WARN:   "warn",
+	ERROR:  "error",
+	DEBUG:  "debug",
+	FATAL:  "fatal",
+	PANIC:  "panic",
+	TRACE:  "trace",
+	NOTICE: "notice",
 }
 
 func getDescription(level LogLevel) string {
@@ -45,3 +68,68 @@ func getDescription(level LogLevel) string {
 	}
 	return l
 }
+
+// builtinLevels are the levels of this package, from the least to the most severe
+var builtinLevels = []LogLevel{TRACE, DEBUG, INFO, NOTICE, WARN, ERROR, PANIC, FATAL}
+
+var levelSeverity = map[LogLevel]int{
+	TRACE:  10,
+	DEBUG:  20,
+	INFO:   30,
+	NOTICE: 35,
+	WARN:   40,
+	ERROR:  50,
+	PANIC:  60,
+	FATAL:  70,
+}
+
+var levelSyslogSeverity = map[LogLevel]int{
+	TRACE:  7, // debug
+	DEBUG:  7, // debug
+	INFO:   6, // info
+	NOTICE: 5, // notice
+	WARN:   4, // warning
+	ERROR:  3, // err
+	PANIC:  2, // crit
+	FATAL:  2, // crit
+}
+
+// LevelSeverity returns the severity of the level. Built-in levels go from TRACE (10) to FATAL (70)
+func LevelSeverity(level LogLevel) int {
+	return levelSeverity[level]
+}
+
+// SyslogSeverity returns the syslog severity (0 to 7, as in RFC 5424) of the level
+func SyslogSeverity(level LogLevel) int {
+	return levelSyslogSeverity[level]
+}
+
+// RegisterLevel registers a custom level with a single letter (shown in the pipe format), a name (shown in the JSON format),
+// a severity (compared with the built-in levels, see LevelSeverity) and a color (like aurora.Blue).
+// The syslog severity is the one of the most severe built-in level below it. Should be called before any logging, like in a init function
+func RegisterLevel(letter, name string, severity int, color func(interface{}) aurora.Value) LogLevel {
+	operationsLock.Lock()
+	defer operationsLock.Unlock()
+
+	level := LogLevel(letter)
+
+	syslogSeverity := levelSyslogSeverity[TRACE]
+	for _, l := range builtinLevels {
+		if levelSeverity[l] <= severity {
+			syslogSeverity = levelSyslogSeverity[l]
+		}
+	}
+
+	levelColors[level] = color
+	levelDescription[level] = name
+	levelSeverity[level] = severity
+	levelSyslogSeverity[level] = syslogSeverity
+	enabledLevels[level] = threshold == "" || severity >= levelSeverity[threshold]
+
+	return level
+}
+
+// atLeast returns if the level is as or more severe than the specified threshold
+func atLeast(level, threshold LogLevel) bool {
+	return levelSeverity[level] >= levelSeverity[threshold]
+}
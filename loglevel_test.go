@@ -1,7 +1,11 @@
 package slog
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+
+	"github.com/logrusorgru/aurora"
 )
 
 func TestGetDescription(t *testing.T) {
@@ -32,11 +36,26 @@ func TestGetDescription(t *testing.T) {
 			level:               FATAL,
 			expectedDescription: "fatal",
 		},
+		{
+			name:                "input is PANIC",
+			level:               PANIC,
+			expectedDescription: "panic",
+		},
 		{
 			name:                "input is DEBUG",
 			level:               DEBUG,
 			expectedDescription: "debug",
 		},
+		{
+			name:                "input is TRACE",
+			level:               TRACE,
+			expectedDescription: "trace",
+		},
+		{
+			name:                "input is NOTICE",
+			level:               NOTICE,
+			expectedDescription: "notice",
+		},
 		{
 			name:                "input with no description",
 			level:               LA,
@@ -53,3 +72,84 @@ func TestGetDescription(t *testing.T) {
 		})
 	}
 }
+
+func TestLevels(t *testing.T) {
+	defer func() {
+		UnsetTestMode()
+		SetTrace(false)
+		threshold = ""
+	}()
+
+	buff := bytes.NewBufferString("")
+	i := Scope("Levels").WithCustomWriter(buff)
+
+	SetLevel(NOTICE)
+	i.Trace("trace message")
+	i.Debug("debug message")
+	i.Info("info message")
+	i.Notice("notice message")
+	i.Warn("warn message")
+
+	o := stripColors(buff.String())
+	if strings.Contains(o, "trace message") || strings.Contains(o, "debug message") || strings.Contains(o, "info message") {
+		t.Errorf("Expected messages below NOTICE to be hidden: %q", o)
+	}
+
+	if !strings.Contains(o, "| N |") || !strings.Contains(o, "| W |") {
+		t.Errorf("Expected NOTICE and WARN messages: %q", o)
+	}
+
+	buff.Reset()
+	SetLevel(TRACE)
+	i.Trace("trace message")
+	if !strings.Contains(stripColors(buff.String()), "| T |") {
+		t.Errorf("Expected TRACE message: %q", buff.String())
+	}
+}
+
+func TestRegisterLevel(t *testing.T) {
+	SetLogFormat(JSON)
+	defer func() {
+		SetLogFormat(PIPE)
+		for _, m := range []map[LogLevel]int{levelSeverity, levelSyslogSeverity} {
+			delete(m, "S")
+		}
+		delete(levelDescription, "S")
+		delete(levelColors, "S")
+		delete(enabledLevels, "S")
+	}()
+
+	SECURITY := RegisterLevel("S", "security", LevelSeverity(WARN)+5, aurora.BrightYellow)
+
+	if !LevelEnabled(SECURITY) {
+		t.Errorf("Expected registered level to be enabled")
+	}
+
+	if SyslogSeverity(SECURITY) != SyslogSeverity(WARN) {
+		t.Errorf("Expected syslog severity %d got %d", SyslogSeverity(WARN), SyslogSeverity(SECURITY))
+	}
+
+	if !atLeast(SECURITY, WARN) || atLeast(SECURITY, ERROR) {
+		t.Errorf("Expected level between WARN and ERROR")
+	}
+
+	buff := bytes.NewBufferString("")
+	Scope("Levels").WithCustomWriter(buff).LogAt(SECURITY, "Login failed")
+
+	if !strings.Contains(buff.String(), `"level":"security"`) {
+		t.Errorf("Expected level name in output: %q", buff.String())
+	}
+
+	SetTestMode()
+	buff.Reset()
+	Scope("Levels").WithCustomWriter(buff).LogAt(SECURITY, "Login failed")
+
+	if LevelEnabled(SECURITY) || buff.Len() != 0 {
+		t.Errorf("Expected registered level to be disabled in test mode: %q", buff.String())
+	}
+
+	UnsetTestMode()
+	if !LevelEnabled(SECURITY) {
+		t.Errorf("Expected registered level to be enabled after test mode")
+	}
+}
@@ -0,0 +1,168 @@
+package slog
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultPipeLayout is the layout of the PIPE format when no other is set
+const DefaultPipeLayout = "{time} {level} {op} {tag} {scope} {caller} {msg} {fields}"
+
+// Alignment defines how a value is aligned in a PIPE format column
+type Alignment int
+
+const (
+	// AlignLeft pads the value on the right (default)
+	AlignLeft Alignment = iota
+	// AlignRight pads the value on the left
+	AlignRight
+)
+
+// PipeColumn configures a column of the PIPE format
+type PipeColumn struct {
+	// Width is the minimum width of the column, padded with spaces. When zero, the scope uses SetScopeLength and the operation uses the longest operation name
+	Width int
+	// MaxWidth truncates longer values. Zero is unlimited
+	MaxWidth int
+	Align    Alignment
+	Hidden   bool
+}
+
+// pipeToken is a column of the layout, with the literal text around its placeholder
+type pipeToken struct {
+	name   string
+	prefix string
+	suffix string
+}
+
+var pipeColumnNames = map[string]bool{
+	"time":   true,
+	"level":  true,
+	"op":     true,
+	"tag":    true,
+	"scope":  true,
+	"caller": true,
+	"msg":    true,
+	"fields": true,
+}
+
+var (
+	pipeLayout    []pipeToken
+	pipeColumns   = map[string]PipeColumn{}
+	pipeSeparator = " | "
+)
+
+func init() {
+	_ = SetPipeLayout(DefaultPipeLayout)
+}
+
+var pipePlaceholderRgx = regexp.MustCompile(`\{([a-z]+)(?::([<>]?)(\d*)(?:\.(\d+))?)?\}`)
+
+// SetPipeLayout sets the columns of the PIPE format. The layout has whitespace separated columns like {name} or {name:spec},
+// where the spec is an optional alignment (< or >), a width and an optional maximum width after a dot, like {scope:>16.16}.
+// The columns are time, level, op, tag, scope, caller (only shown if SetShowLines is enabled), msg and fields.
+// Literal text around a placeholder is kept, like [{tag}]. Each column has a single placeholder and is used once. Discards the options set by SetPipeColumn
+func SetPipeLayout(layout string) error {
+	var tokens []pipeToken
+	columns := map[string]PipeColumn{}
+
+	for _, field := range strings.Fields(layout) {
+		m := pipePlaceholderRgx.FindStringSubmatchIndex(field)
+		if m == nil {
+			return fmt.Errorf("invalid pipe layout column %q", field)
+		}
+
+		if len(pipePlaceholderRgx.FindAllStringIndex(field, 2)) > 1 {
+			return fmt.Errorf("pipe layout column %q has more than one placeholder", field)
+		}
+
+		name := field[m[2]:m[3]]
+		if !pipeColumnNames[name] {
+			return fmt.Errorf("unknown pipe layout column %q", name)
+		}
+
+		if _, ok := columns[name]; ok {
+			return fmt.Errorf("repeated pipe layout column %q", name)
+		}
+
+		c := PipeColumn{}
+		if m[4] > -1 && field[m[4]:m[5]] == ">" {
+			c.Align = AlignRight
+		}
+		if m[6] > -1 && m[7] > m[6] {
+			c.Width, _ = strconv.Atoi(field[m[6]:m[7]])
+		}
+		if m[8] > -1 {
+			c.MaxWidth, _ = strconv.Atoi(field[m[8]:m[9]])
+		}
+
+		tokens = append(tokens, pipeToken{name: name, prefix: field[:m[0]], suffix: field[m[1]:]})
+		columns[name] = c
+	}
+
+	if len(tokens) == 0 {
+		return fmt.Errorf("empty pipe layout")
+	}
+
+	pipeLayout = tokens
+	pipeColumns = columns
+	return nil
+}
+
+// SetPipeColumn sets the options of a PIPE format column
+func SetPipeColumn(name string, c PipeColumn) error {
+	if !pipeColumnNames[name] {
+		return fmt.Errorf("unknown pipe layout column %q", name)
+	}
+
+	columns := map[string]PipeColumn{}
+	for k, v := range pipeColumns {
+		columns[k] = v
+	}
+	columns[name] = c
+	pipeColumns = columns
+	return nil
+}
+
+// SetPipeSeparator sets the text between the PIPE format columns. Defaults to " | "
+func SetPipeSeparator(sep string) {
+	pipeSeparator = sep
+}
+
+// fit truncates and pads the value to the column width
+func (c PipeColumn) fit(text string, defaultWidth int) string {
+	if c.MaxWidth > 0 && len(text) > c.MaxWidth {
+		cut := c.MaxWidth
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		text = text[:cut]
+	}
+
+	width := c.Width
+	if width == 0 {
+		width = defaultWidth
+	}
+	if c.MaxWidth > 0 && width > c.MaxWidth {
+		width = c.MaxWidth
+	}
+
+	if c.Align == AlignRight {
+		return padLeft(text, width)
+	}
+	return padRight(text, width)
+}
+
+// defaultColumnWidth returns the width of a column without an explicit one
+func defaultColumnWidth(name string) int {
+	switch name {
+	case "scope":
+		return scopeLength
+	case "op":
+		return operationLength()
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func resetPipeLayout() {
+	_ = SetPipeLayout(DefaultPipeLayout)
+	SetPipeSeparator(" | ")
+}
+
+func TestSetPipeLayout(t *testing.T) {
+	UnsetTestMode()
+	defer resetPipeLayout()
+
+	if err := SetPipeLayout("{level} [{tag:>6}] {scope:4.4} {msg} {fields}"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	SetPipeSeparator(" ")
+
+	buff := bytes.NewBufferString("")
+	Scope("Layout").Tag("R1").WithCustomWriter(buff).Info("Hello")
+
+	expected := "I [    R1] Layo Hello  {}" + LineBreak
+	if stripColors(buff.String()) != expected {
+		t.Errorf("Expected %q got %q", expected, stripColors(buff.String()))
+	}
+}
+
+func TestSetPipeLayoutErrors(t *testing.T) {
+	defer resetPipeLayout()
+
+	for _, layout := range []string{"{time} {unknown}", "{time} text", "{scope:24x}", "", "  ", "[{tag}]{scope}", "{msg} {msg}"} {
+		if err := SetPipeLayout(layout); err == nil {
+			t.Errorf("Expected error for layout %q", layout)
+		}
+	}
+
+	if err := SetPipeColumn("unknown", PipeColumn{}); err == nil {
+		t.Errorf("Expected error for unknown column")
+	}
+}
+
+func TestSetPipeColumn(t *testing.T) {
+	UnsetTestMode()
+	defer resetPipeLayout()
+	defer SetShowLines(showLines)
+	SetShowLines(false)
+
+	_ = SetPipeColumn("time", PipeColumn{Hidden: true})
+	_ = SetPipeColumn("op", PipeColumn{Width: 7, Align: AlignRight})
+	_ = SetPipeColumn("scope", PipeColumn{MaxWidth: 3})
+
+	buff := bytes.NewBufferString("")
+	Scope("Column").WithCustomWriter(buff).Done("Hello")
+
+	expected := "I |    DONE | NONE | Col | Hello  | {}" + LineBreak
+	if stripColors(buff.String()) != expected {
+		t.Errorf("Expected %q got %q", expected, stripColors(buff.String()))
+	}
+}
+
+func TestPipeLayoutMultiLine(t *testing.T) {
+	UnsetTestMode()
+	defer resetPipeLayout()
+
+	_ = SetPipeLayout("{level} {tag} {msg} {fields}")
+	SetPipeSeparator(" :: ")
+
+	buff := bytes.NewBufferString("")
+	Scope("M").Tag("T").WithCustomWriter(buff).Info("first\nsecond")
+
+	lines := strings.Split(stripColors(buff.String()), LineBreak)
+	if len(lines) < 2 {
+		t.Fatalf("Expected multi-line output: %q", buff.String())
+	}
+
+	if strings.Index(lines[0], "first") != strings.Index(lines[1], "second") {
+		t.Errorf("Expected message lines to be aligned: %q", buff.String())
+	}
+}
+
+func TestFit(t *testing.T) {
+	cases := []struct {
+		column   PipeColumn
+		text     string
+		expected string
+	}{
+		{PipeColumn{}, "abc", "abc"},
+		{PipeColumn{Width: 5}, "abc", "abc  "},
+		{PipeColumn{Width: 5, Align: AlignRight}, "abc", "  abc"},
+		{PipeColumn{MaxWidth: 2}, "abc", "ab"},
+		{PipeColumn{MaxWidth: 2}, "ãé", "ã"},
+	}
+
+	for _, c := range cases {
+		if got := c.column.fit(c.text, 0); got != c.expected {
+			t.Errorf("Expected %q got %q", c.expected, got)
+		}
+	}
+}
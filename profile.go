@@ -0,0 +1,117 @@
+package slog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ProfileEnv is the environment variable that selects the profile applied when the program starts: production (or prod) and development (or dev)
+const ProfileEnv = "SLOG_PROFILE"
+
+// Profile is a set of global settings applied at once by UseProfile. Presets are returned by ProductionProfile and DevelopmentProfile,
+// and can be changed before being applied
+type Profile struct {
+	Format    Format
+	ColorMode ColorMode
+	// Level is the least severe level shown, as in SetLevel
+	Level     LogLevel
+	ShowLines bool
+	// CallerLevel is the least severe level that shows the caller, as in SetCallerLevel. Empty shows it in all levels
+	CallerLevel  LogLevel
+	TimeFormat   string
+	TimeLocation *time.Location
+	// AsyncQueueSize is the queue size of the async output, as in SetAsyncOutput. Zero disables it
+	AsyncQueueSize int
+	ScopeLength    int
+	// Fields are logged by all instances, as in SetGlobalFields. Nil keeps the current global fields
+	Fields map[string]interface{}
+}
+
+// ProductionProfile returns the production preset: JSON without colors, INFO level, caller on ERROR and more severe levels,
+// UTC time with nanoseconds, async output and the service, hostname and build info fields
+func ProductionProfile() Profile {
+	return Profile{
+		Format:         JSON,
+		ColorMode:      ColorNever,
+		Level:          INFO,
+		ShowLines:      true,
+		CallerLevel:    ERROR,
+		TimeFormat:     time.RFC3339Nano,
+		TimeLocation:   time.UTC,
+		AsyncQueueSize: 1024,
+		ScopeLength:    scopeLength,
+		Fields:         productionFields(),
+	}
+}
+
+// DevelopmentProfile returns the development preset: pipe format colored when writing to a terminal, DEBUG level, caller on all levels, local time and a wide scope column
+func DevelopmentProfile() Profile {
+	return Profile{
+		Format:      PIPE,
+		ColorMode:   ColorAuto,
+		Level:       DEBUG,
+		ShowLines:   true,
+		TimeFormat:  time.RFC3339,
+		ScopeLength: 40,
+	}
+}
+
+// UseProfile applies the settings of the profile. Affects all instances
+func UseProfile(p Profile) {
+	SetLogFormat(p.Format)
+	SetColorMode(p.ColorMode)
+	SetLevel(p.Level)
+	SetShowLines(p.ShowLines)
+	SetCallerLevel(p.CallerLevel)
+	SetTimeFormat(p.TimeFormat)
+	SetTimeLocation(p.TimeLocation)
+	SetAsyncOutput(p.AsyncQueueSize)
+	SetScopeLength(p.ScopeLength)
+	if p.Fields != nil {
+		SetGlobalFields(p.Fields)
+	}
+}
+
+// UseProduction applies the ProductionProfile. Settings can be overridden afterwards with the Set functions
+func UseProduction() {
+	UseProfile(ProductionProfile())
+}
+
+// UseDevelopment applies the DevelopmentProfile. Settings can be overridden afterwards with the Set functions
+func UseDevelopment() {
+	UseProfile(DevelopmentProfile())
+}
+
+// UseEnvProfile applies the profile selected by the SLOG_PROFILE environment variable, if set. It is called when the program starts.
+// The async output of the production profile is left disabled, as the program may not call Flush before exiting. Use UseProfile to enable it
+func UseEnvProfile() error {
+	var p Profile
+
+	switch name := strings.ToLower(strings.TrimSpace(os.Getenv(ProfileEnv))); name {
+	case "":
+		return nil
+	case "production", "prod":
+		p = ProductionProfile()
+		p.AsyncQueueSize = 0
+	case "development", "dev":
+		p = DevelopmentProfile()
+	default:
+		return fmt.Errorf("unknown %s %q, expected production or development", ProfileEnv, name)
+	}
+
+	UseProfile(p)
+	return nil
+}
+
+// productionFields returns the global fields of the production profile
+func productionFields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, provider := range []FieldProvider{ServiceFields, HostnameFields, BuildInfoFields} {
+		for k, v := range provider() {
+			fields[k] = v
+		}
+	}
+	return fields
+}
@@ -0,0 +1,163 @@
+package slog
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// resetProfile restores the default settings changed by the profiles
+func resetProfile() {
+	UseProfile(Profile{
+		Format:      PIPE,
+		ColorMode:   ColorAuto,
+		Level:       DEBUG,
+		TimeFormat:  time.RFC3339,
+		ScopeLength: 24,
+	})
+	SetGlobalFields(nil)
+	threshold = ""
+	UnsetTestMode()
+}
+
+func TestUseProduction(t *testing.T) {
+	defer resetProfile()
+	UseProduction()
+
+	if !AsyncOutputEnabled() || DebugEnabled() || !InfoEnabled() || !ShowLinesEnabled() {
+		t.Errorf("Expected async output, INFO level and lines shown")
+	}
+
+	buff := &lockedBuffer{}
+	if ColorsEnabled(buff) {
+		t.Errorf("Expected no colors")
+	}
+
+	i := Scope("Production").WithCustomWriter(buff)
+	i.Debug("hidden")
+	i.Info("started")
+	i.WithFields(map[string]interface{}{"service": "api"}).Error("failed")
+	Flush()
+
+	entries := jsonEntries(t, buff)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 lines got %v", entries)
+	}
+
+	if entries[0]["service"] == nil || entries[0]["hostname"] == nil || entries[0]["lines"] != nil {
+		t.Errorf("Expected service and hostname fields without caller got %v", entries[0])
+	}
+
+	if entries[1]["service"] != "api" || entries[1]["lines"] == nil {
+		t.Errorf("Expected instance service field and caller got %v", entries[1])
+	}
+
+	if !strings.HasSuffix(entries[0]["time"].(string), "Z") {
+		t.Errorf("Expected UTC time got %v", entries[0]["time"])
+	}
+}
+
+func TestUseDevelopment(t *testing.T) {
+	defer resetProfile()
+	SetGlobalFields(map[string]interface{}{"env": "local"})
+	UseDevelopment()
+
+	if AsyncOutputEnabled() || !DebugEnabled() || !ShowLinesEnabled() || scopeLength != 40 || logFormat != PIPE {
+		t.Errorf("Expected synchronous pipe output, DEBUG level, lines shown and wide scope")
+	}
+
+	if ColorsEnabled(&lockedBuffer{}) {
+		t.Errorf("Expected no colors when not writing to a terminal")
+	}
+
+	if GlobalFields()["env"] != "local" {
+		t.Errorf("Expected global fields to be kept got %v", GlobalFields())
+	}
+}
+
+func TestUseProfileOverride(t *testing.T) {
+	defer resetProfile()
+
+	p := ProductionProfile()
+	p.AsyncQueueSize = 0
+	p.Fields["env"] = "staging"
+	UseProfile(p)
+	p.Fields["env"] = "changed"
+
+	buff := &lockedBuffer{}
+	Scope("Override").WithCustomWriter(buff).Info("started")
+
+	entries := jsonEntries(t, buff)
+	if AsyncOutputEnabled() || entries[0]["env"] != "staging" {
+		t.Errorf("Expected synchronous output and env field got %v", entries[0])
+	}
+}
+
+func TestUseEnvProfile(t *testing.T) {
+	defer resetProfile()
+
+	t.Setenv(ProfileEnv, "Dev")
+	if err := UseEnvProfile(); err != nil || scopeLength != 40 {
+		t.Errorf("Expected development profile got %v", err)
+	}
+
+	t.Setenv(ProfileEnv, "prod")
+	if err := UseEnvProfile(); err != nil || logFormat != JSON || AsyncOutputEnabled() {
+		t.Errorf("Expected production profile without async output got %v", err)
+	}
+
+	t.Setenv(ProfileEnv, "staging")
+	if err := UseEnvProfile(); err == nil {
+		t.Errorf("Expected error for unknown profile")
+	}
+}
+
+func TestInvalidEnvProfile(t *testing.T) {
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	cmd.Env = append(os.Environ(), ProfileEnv+"=bogus")
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Expected program to start with an unknown profile got %s: %s", err, out)
+	}
+
+	if !strings.Contains(string(out), `unknown SLOG_PROFILE "bogus", expected production or development`) {
+		t.Errorf("Expected warning about the unknown profile got %q", out)
+	}
+}
+
+func TestAsyncOutput(t *testing.T) {
+	UnsetTestMode()
+	SetAsyncOutput(2)
+	defer SetAsyncOutput(0)
+	defer SetExitFunc(nil)
+
+	exitCodes := []int{}
+	SetExitFunc(func(code int) {
+		exitCodes = append(exitCodes, code)
+	})
+
+	buff := &lockedBuffer{}
+	i := Scope("Async").WithCustomWriter(buff)
+	for n := 0; n < 10; n++ {
+		i.Info("line %d", n)
+	}
+	i.Fatal("stopping")
+
+	lines := strings.Split(strings.TrimSpace(buff.String()), LineBreak)
+	if len(lines) < 11 || len(exitCodes) != 1 {
+		t.Fatalf("Expected all lines written before the exit got %q", lines)
+	}
+
+	for n := 0; n < 10; n++ {
+		if !strings.HasSuffix(lines[n], "line "+string(rune('0'+n))+" | {}") {
+			t.Errorf("Expected line %d in order got %q", n, lines[n])
+		}
+	}
+
+	if !strings.Contains(lines[10], "stopping") {
+		t.Errorf("Expected fatal line last got %q", lines[10])
+	}
+}
@@ -0,0 +1,81 @@
+package slog
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// RecoverMode specifies what happens after a recovered panic is logged
+type RecoverMode int
+
+const (
+	// Swallow logs the panic in ERROR level and resumes the normal execution
+	Swallow RecoverMode = iota
+	// Repanic logs the panic in FATAL level and panics again with the same value
+	Repanic
+)
+
+const (
+	panicField = "panic"
+	stackField = "stack"
+)
+
+// Recover recovers from a panic and logs it with the panic value and stack trace as fields. Should be called directly with defer: defer log.Recover()
+func (i *slogInstance) Recover(mode ...RecoverMode) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	var level LogLevel = ERROR
+	if len(mode) > 0 && mode[0] == Repanic {
+		level = FATAL
+	}
+
+	if level == FATAL || enabledLevels[level] {
+		l := i.WithFields(map[string]interface{}{
+			panicField: fmt.Sprint(r),
+			stackField: panicStack(),
+		}).(*slogInstance)
+		l.record(level, "Recovered from panic: %v", []interface{}{r})
+		l.log("Recovered from panic: %v", level, r)
+	}
+
+	if level == FATAL {
+		panic(r)
+	}
+}
+
+// Go runs f in a new goroutine, logging any panic with Recover
+func Go(inst Instance, f func(), mode ...RecoverMode) {
+	go func() {
+		defer inst.Recover(mode...)
+		f()
+	}()
+}
+
+// panicStack returns the stack of the panicking goroutine, starting at the function that panicked. Frames internal to the library are left out
+func panicStack() []string {
+	pc := make([]uintptr, 64)
+	n := runtime.Callers(1, pc)
+	frames := runtime.CallersFrames(pc[:n])
+
+	var stack []string
+	panicking := false
+
+	for {
+		frame, more := frames.Next()
+		switch {
+		case frame.Function == "runtime.gopanic":
+			panicking = true
+		case panicking && !internalFrame(frame):
+			stack = append(stack, formatFrame(frame))
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
@@ -0,0 +1,95 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	UnsetTestMode()
+	SetLogFormat(JSON)
+	defer SetLogFormat(PIPE)
+
+	buff := bytes.NewBufferString("")
+	i := Scope("Recover").Tag("REQ1").WithCustomWriter(buff)
+
+	func() {
+		defer i.Recover()
+		panic("something broke")
+	}()
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(buff.Bytes(), &values); err != nil {
+		t.Fatalf("Cannot parse output %q: %s", buff.String(), err)
+	}
+
+	if values["level"] != "error" {
+		t.Errorf("Got %q want %q.", values["level"], "error")
+	}
+
+	if values["panic"] != "something broke" {
+		t.Errorf("Got %q want %q.", values["panic"], "something broke")
+	}
+
+	if values["tag"] != "REQ1" {
+		t.Errorf("Got %q want %q.", values["tag"], "REQ1")
+	}
+
+	stack, _ := values["stack"].([]interface{})
+	if len(stack) == 0 || !strings.Contains(stack[0].(string), "TestRecover") {
+		t.Errorf("Expected stack to start at the panicking function, got %v", values["stack"])
+	}
+}
+
+func TestRecoverRepanic(t *testing.T) {
+	buff := bytes.NewBufferString("")
+	i := Scope("Recover").WithCustomWriter(buff)
+
+	defer func() {
+		if r := recover(); r != "something broke" {
+			t.Errorf("Expected repanic with the same value, got %v", r)
+		}
+		if !strings.Contains(buff.String(), "something broke") {
+			t.Errorf("Expected panic to be logged, got %q", buff.String())
+		}
+	}()
+
+	defer i.Recover(Repanic)
+	panic("something broke")
+}
+
+type notifyWriter struct {
+	lockedBuffer
+	wg *sync.WaitGroup
+}
+
+func (w *notifyWriter) Write(p []byte) (int, error) {
+	defer w.wg.Done()
+	return w.lockedBuffer.Write(p)
+}
+
+func TestGo(t *testing.T) {
+	UnsetTestMode()
+	defer SetFieldRepresentation(fieldRepresentation)
+	SetFieldRepresentation(JSONFields)
+	wg := &sync.WaitGroup{}
+	buff := &notifyWriter{wg: wg}
+	i := Scope("Go").WithCustomWriter(buff)
+
+	wg.Add(1)
+	Go(i, func() {
+		panic("goroutine broke")
+	})
+	wg.Wait()
+
+	if !strings.Contains(buff.String(), "goroutine broke") {
+		t.Errorf("Expected panic to be logged, got %q", buff.String())
+	}
+
+	if strings.Contains(buff.String(), "recover.go") || !strings.Contains(buff.String(), "recover_test.go") {
+		t.Errorf("Expected stack without library frames, got %q", buff.String())
+	}
+}
@@ -3,6 +3,7 @@ package slog
 import (
 	"io"
 	"os"
+	"slices"
 	"strings"
 )
 
@@ -44,13 +45,19 @@ func ToFormat(s string) Format {
 
 // region Global
 var enabledLevels = map[LogLevel]bool{
-	DEBUG: true,
-	WARN:  true,
-	ERROR: true,
-	INFO:  true,
-	FATAL: true,
+	DEBUG:  true,
+	WARN:   true,
+	ERROR:  true,
+	INFO:   true,
+	FATAL:  true,
+	PANIC:  true,
+	TRACE:  false,
+	NOTICE: true,
 }
 
+// threshold is the minimum level set by SetLevel. Empty when the levels are set individually
+var threshold LogLevel
+
 var fieldRepresentation = JSONFields
 var logFormat = PIPE
 var defaultOut io.Writer = os.Stdout
@@ -61,7 +68,10 @@ var glog *slogInstance
 
 func init() {
 	glog = Scope("Global").(*slogInstance)
-	glog.stackOffset += 1 // This will be called from global context, so the stack has one more level
+
+	if err := UseEnvProfile(); err != nil {
+		glog.Warn("%s", err.Error())
+	}
 }
 
 // LogNoFormat prints a log string without any ANSI formatting
@@ -84,6 +94,21 @@ func Debug(str interface{}, v ...interface{}) Instance {
 	return glog.Debug(str, v...)
 }
 
+// Trace logs out a message in TRACE level
+func Trace(str interface{}, v ...interface{}) Instance {
+	return glog.Trace(str, v...)
+}
+
+// Notice logs out a message in NOTICE level
+func Notice(str interface{}, v ...interface{}) Instance {
+	return glog.Notice(str, v...)
+}
+
+// LogAt logs out a message in the specified level. FATAL and PANIC levels don't close the program or panic
+func LogAt(level LogLevel, str interface{}, v ...interface{}) Instance {
+	return glog.LogAt(level, str, v...)
+}
+
 // Warn logs out a message in WARN level
 func Warn(str interface{}, v ...interface{}) Instance {
 	return glog.Warn(str, v...)
@@ -94,19 +119,103 @@ func Error(str interface{}, v ...interface{}) Instance {
 	return glog.Error(str, v...)
 }
 
-// Fatal logs out a message in ERROR level and closes the program
+// Fatal logs out a message in FATAL level, runs the exit hooks and closes the program
 func Fatal(str interface{}, v ...interface{}) {
-	glog.Fatal(str, v)
+	glog.Fatal(str, v...)
+}
+
+// Panic logs out a message in PANIC level and panics with the message
+func Panic(str interface{}, v ...interface{}) {
+	glog.Panic(str, v...)
+}
+
+// Debugf logs out a message in DEBUG level. The message is always handled as a printf format string
+func Debugf(format string, v ...interface{}) Instance {
+	return glog.Debugf(format, v...)
+}
+
+// Debugln logs out a message in DEBUG level. The arguments are joined by spaces, without any format string
+func Debugln(v ...interface{}) Instance {
+	return glog.Debugln(v...)
+}
+
+// Infof logs out a message in INFO level. The message is always handled as a printf format string
+func Infof(format string, v ...interface{}) Instance {
+	return glog.Infof(format, v...)
+}
+
+// Infoln logs out a message in INFO level. The arguments are joined by spaces, without any format string
+func Infoln(v ...interface{}) Instance {
+	return glog.Infoln(v...)
+}
+
+// Warnf logs out a message in WARN level. The message is always handled as a printf format string
+func Warnf(format string, v ...interface{}) Instance {
+	return glog.Warnf(format, v...)
+}
+
+// Warnln logs out a message in WARN level. The arguments are joined by spaces, without any format string
+func Warnln(v ...interface{}) Instance {
+	return glog.Warnln(v...)
+}
+
+// Errorln logs out a message in ERROR level. The arguments are joined by spaces, without any format string
+func Errorln(v ...interface{}) Instance {
+	return glog.Errorln(v...)
+}
+
+// Tracef logs out a message in TRACE level. The message is always handled as a printf format string
+func Tracef(format string, v ...interface{}) Instance {
+	return glog.Tracef(format, v...)
+}
+
+// Traceln logs out a message in TRACE level. The arguments are joined by spaces, without any format string
+func Traceln(v ...interface{}) Instance {
+	return glog.Traceln(v...)
+}
+
+// Noticef logs out a message in NOTICE level. The message is always handled as a printf format string
+func Noticef(format string, v ...interface{}) Instance {
+	return glog.Noticef(format, v...)
+}
+
+// Noticeln logs out a message in NOTICE level. The arguments are joined by spaces, without any format string
+func Noticeln(v ...interface{}) Instance {
+	return glog.Noticeln(v...)
+}
+
+// Errorf logs out a message in ERROR level and returns it as an error carrying the instance scope, tag and fields
+func Errorf(format string, v ...interface{}) error {
+	return glog.Errorf(format, v...)
+}
+
+// Fatalf logs out a message in FATAL level. The message is always handled as a printf format string
+func Fatalf(format string, v ...interface{}) {
+	glog.Fatalf(format, v...)
+}
+
+// Fatalln logs out a message in FATAL level. The arguments are joined by spaces, without any format string
+func Fatalln(v ...interface{}) {
+	glog.Fatalln(v...)
+}
+
+// Panicf logs out a message in PANIC level. The message is always handled as a printf format string
+func Panicf(format string, v ...interface{}) {
+	glog.Panicf(format, v...)
+}
+
+// Panicln logs out a message in PANIC level. The arguments are joined by spaces, without any format string
+func Panicln(v ...interface{}) {
+	glog.Panicln(v...)
 }
 
 // Scope creates a new slog Instance with the specified root scope
 func Scope(scope string) Instance {
 	return &slogInstance{
-		scope:       []string{scope},
-		customOut:   defaultOut,
-		stackOffset: 5,
-		tag:         "NONE",
-		op:          MSG,
+		scope:     []string{scope},
+		customOut: defaultOut,
+		tag:       "NONE",
+		op:        MSG,
 	}
 }
 
@@ -136,6 +245,29 @@ func SetError(enabled bool) {
 	enabledLevels[ERROR] = enabled
 }
 
+// SetTrace globally sets if the TRACE level messages will be shown. Affects all instances
+func SetTrace(enabled bool) {
+	enabledLevels[TRACE] = enabled
+}
+
+// SetNotice globally sets if the NOTICE level messages will be shown. Affects all instances
+func SetNotice(enabled bool) {
+	enabledLevels[NOTICE] = enabled
+}
+
+// SetLevelEnabled globally sets if the messages of the specified level will be shown. Affects all instances
+func SetLevelEnabled(level LogLevel, enabled bool) {
+	enabledLevels[level] = enabled
+}
+
+// SetLevel globally shows only the messages as or more severe than the specified level (see LevelSeverity). Affects all instances
+func SetLevel(level LogLevel) {
+	threshold = level
+	for l := range enabledLevels {
+		enabledLevels[l] = atLeast(l, level)
+	}
+}
+
 // SetShowLines globally sets if the filename and line of the caller function will be shown. Affects all instances
 func SetShowLines(enabled bool) {
 	showLines = enabled
@@ -151,16 +283,32 @@ func SetLogFormat(f Format) {
 	logFormat = f
 }
 
-// SetTestMode sets the SLog Instances to test mode a.k.a. all logs disabled. Equivalent to set all levels visibility to false
+// SetTestMode sets the SLog Instances to test mode a.k.a. all logs disabled. Equivalent to set all levels visibility to false,
+// including the ones registered with RegisterLevel. FATAL and PANIC are kept
 func SetTestMode() {
-	SetDebug(false)
-	SetWarning(false)
-	SetInfo(false)
-	SetError(false)
+	operationsLock.Lock()
+	defer operationsLock.Unlock()
+
+	for l := range enabledLevels {
+		if l != FATAL && l != PANIC {
+			enabledLevels[l] = false
+		}
+	}
 }
 
-// UnsetTestMode sets the SLog Instances to default mode a.k.a. all logs enabled. Equivalent to set all levels visibility to true
+// UnsetTestMode sets the SLog Instances to default mode a.k.a. all logs enabled, except TRACE. Equivalent to set all levels visibility to true,
+// including the ones registered with RegisterLevel
 func UnsetTestMode() {
+	operationsLock.Lock()
+	defer operationsLock.Unlock()
+
+	for l := range enabledLevels {
+		if !slices.Contains(builtinLevels, l) {
+			enabledLevels[l] = true
+		}
+	}
+
+	SetNotice(true)
 	SetDebug(true)
 	SetWarning(true)
 	SetInfo(true)
@@ -187,6 +335,21 @@ func ErrorEnabled() bool {
 	return enabledLevels[ERROR]
 }
 
+// TraceEnabled returns if the TRACE level messages are currently enabled
+func TraceEnabled() bool {
+	return enabledLevels[TRACE]
+}
+
+// NoticeEnabled returns if the NOTICE level messages are currently enabled
+func NoticeEnabled() bool {
+	return enabledLevels[NOTICE]
+}
+
+// LevelEnabled returns if the messages of the specified level are currently enabled
+func LevelEnabled(level LogLevel) bool {
+	return enabledLevels[level]
+}
+
 // ShowLinesEnabled returns if the show filename and line from called function is currently enabled
 func ShowLinesEnabled() bool {
 	return showLines
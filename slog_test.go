@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"github.com/bouk/monkey"
 	"os"
 	"strings"
 	"testing"
@@ -393,8 +392,8 @@ func TestFatal(t *testing.T) {
 	fakeExit := func(int) {
 		panic("os.Exit called")
 	}
-	patch := monkey.Patch(os.Exit, fakeExit)
-	defer patch.Unpatch()
+	SetExitFunc(fakeExit)
+	defer SetExitFunc(nil)
 
 	assertPanic(t, func() {
 		Fatal("Test Fatal")
@@ -507,7 +506,7 @@ func TestAsString(t *testing.T) {
 func assertPanic(t *testing.T, f func(), message string) {
 	defer func() {
 		if r := recover(); r == nil {
-			t.Errorf(message)
+			t.Error(message)
 		}
 	}()
 	f()
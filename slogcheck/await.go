@@ -0,0 +1,126 @@
+package slogcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func isAwait(name string) bool {
+	return strings.HasSuffix(name, "Await")
+}
+
+func isDone(name string) bool {
+	return strings.HasSuffix(name, "Done") || strings.HasSuffix(name, "Success")
+}
+
+// instanceKey identifies the instance a slog method is called on. Instances derived from the same
+// variable (like l.SubScope("x").Done()) share the key of that variable
+func instanceKey(pass *analysis.Pass, expr ast.Expr) interface{} {
+	for {
+		switch e := expr.(type) {
+		case *ast.ParenExpr:
+			expr = e.X
+			continue
+		case *ast.CallExpr:
+			sel, ok := e.Fun.(*ast.SelectorExpr)
+			if ok && slogFunc(pass, e) != nil {
+				expr = sel.X
+				continue
+			}
+		case *ast.Ident:
+			if obj := pass.TypesInfo.ObjectOf(e); obj != nil {
+				return obj
+			}
+		}
+		return types.ExprString(expr)
+	}
+}
+
+// checkAwait reports Await calls without a matching Done or Success on the same instance in the same function
+func checkAwait(pass *analysis.Pass, fn ast.Node) {
+	var body *ast.BlockStmt
+	switch f := fn.(type) {
+	case *ast.FuncDecl:
+		body = f.Body
+	case *ast.FuncLit:
+		body = f.Body
+	}
+
+	if body == nil {
+		return
+	}
+
+	type await struct {
+		call *ast.CallExpr
+		name string
+		keys []interface{}
+	}
+
+	var awaits []await
+	done := map[interface{}]bool{}
+	assigned := map[*ast.CallExpr]interface{}{}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			// Function literals are checked on their own, but a Done inside them (like in a defer) still counts for the parent
+			if node != fn {
+				collectDone(pass, node, done)
+				return false
+			}
+		case *ast.AssignStmt:
+			if len(node.Lhs) == len(node.Rhs) {
+				for n, rhs := range node.Rhs {
+					if call, ok := rhs.(*ast.CallExpr); ok {
+						assigned[call] = instanceKey(pass, node.Lhs[n])
+					}
+				}
+			}
+		case *ast.CallExpr:
+			f := slogFunc(pass, node)
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if f != nil && ok && isAwait(f.Name()) {
+				awaits = append(awaits, await{call: node, name: f.Name(), keys: []interface{}{instanceKey(pass, sel.X)}})
+			}
+			markDone(pass, node, done)
+		}
+		return true
+	})
+
+	for _, a := range awaits {
+		if key, ok := assigned[a.call]; ok {
+			a.keys = append(a.keys, key)
+		}
+
+		matched := false
+		for _, k := range a.keys {
+			matched = matched || done[k]
+		}
+
+		if !matched {
+			pass.Reportf(a.call.Pos(), "%s without a matching Done or Success on the same instance", a.name)
+		}
+	}
+}
+
+// markDone marks the instance as done if the call is to a Done or Success method
+func markDone(pass *analysis.Pass, call *ast.CallExpr, done map[interface{}]bool) {
+	f := slogFunc(pass, call)
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if f != nil && ok && isDone(f.Name()) {
+		done[instanceKey(pass, sel.X)] = true
+	}
+}
+
+// collectDone marks the instances of all Done and Success calls inside the function literal
+func collectDone(pass *analysis.Pass, lit *ast.FuncLit, done map[interface{}]bool) {
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			markDone(pass, call, done)
+		}
+		return true
+	})
+}
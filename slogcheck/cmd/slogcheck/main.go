@@ -0,0 +1,19 @@
+// Command slogcheck runs the slogcheck analyzer. It can be used standalone or as a go vet tool:
+//
+//	go vet -vettool=$(which slogcheck) ./...
+package main
+
+import (
+	"github.com/quan-to/slog/slogcheck"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(slogcheck.Analyzer)
+}
+
+// New returns the slogcheck analyzers when this package is built as a golangci-lint Go plugin (go build -buildmode=plugin)
+func New(conf any) ([]*analysis.Analyzer, error) {
+	return slogcheck.New(conf)
+}
@@ -0,0 +1,10 @@
+module github.com/quan-to/slog/slogcheck
+
+go 1.24.0
+
+require golang.org/x/tools v0.40.0
+
+require (
+	golang.org/x/mod v0.31.0 // indirect
+	golang.org/x/sync v0.19.0 // indirect
+)
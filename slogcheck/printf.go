@@ -0,0 +1,204 @@
+package slogcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+type formatKind int
+
+const (
+	// autoFormat methods handle a string message with verbs as a format, and any other message as arguments
+	autoFormat formatKind = iota
+	// printfFormat methods always handle the message as a format
+	printfFormat
+	// printlnFormat methods never handle the message as a format
+	printlnFormat
+)
+
+var formatMethods = map[string]formatKind{
+	"Log":          autoFormat,
+	"Info":         autoFormat,
+	"Warn":         autoFormat,
+	"Error":        autoFormat,
+	"Debug":        autoFormat,
+	"Trace":        autoFormat,
+	"Notice":       autoFormat,
+	"Fatal":        autoFormat,
+	"Panic":        autoFormat,
+	"LogNoFormat":  autoFormat,
+	"Note":         autoFormat,
+	"Await":        autoFormat,
+	"Done":         autoFormat,
+	"Success":      autoFormat,
+	"IO":           autoFormat,
+	"WarnNote":     autoFormat,
+	"WarnAwait":    autoFormat,
+	"WarnDone":     autoFormat,
+	"WarnSuccess":  autoFormat,
+	"WarnIO":       autoFormat,
+	"ErrorNote":    autoFormat,
+	"ErrorAwait":   autoFormat,
+	"ErrorDone":    autoFormat,
+	"ErrorSuccess": autoFormat,
+	"ErrorIO":      autoFormat,
+	"DebugNote":    autoFormat,
+	"DebugAwait":   autoFormat,
+	"DebugDone":    autoFormat,
+	"DebugSuccess": autoFormat,
+	"DebugIO":      autoFormat,
+	"Infof":        printfFormat,
+	"Warnf":        printfFormat,
+	"Errorf":       printfFormat,
+	"Debugf":       printfFormat,
+	"Tracef":       printfFormat,
+	"Noticef":      printfFormat,
+	"Fatalf":       printfFormat,
+	"Panicf":       printfFormat,
+	"Infoln":       printlnFormat,
+	"Warnln":       printlnFormat,
+	"Errorln":      printlnFormat,
+	"Debugln":      printlnFormat,
+	"Traceln":      printlnFormat,
+	"Noticeln":     printlnFormat,
+	"Fatalln":      printlnFormat,
+	"Panicln":      printlnFormat,
+}
+
+type verb struct {
+	pos  int
+	char byte
+	args int
+}
+
+// parseVerbs returns the printf verbs in format, following the same rules of the slog library.
+// Returns false if the format uses explicit argument indexes
+func parseVerbs(format string) ([]verb, bool) {
+	var verbs []verb
+
+	for n := 0; n < len(format); n++ {
+		if format[n] != '%' {
+			continue
+		}
+
+		start := n
+		n++
+		if n < len(format) && format[n] == '%' {
+			continue
+		}
+
+		for n < len(format) && strings.IndexByte("+-#0", format[n]) > -1 {
+			n++
+		}
+
+		args := 1
+	scan:
+		for ; n < len(format); n++ {
+			c := format[n]
+			switch {
+			case c >= '0' && c <= '9', c == '.':
+			case c == '*':
+				args++
+			case c == '[':
+				return nil, false
+			case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+				verbs = append(verbs, verb{pos: start, char: c, args: args})
+				break scan
+			default:
+				n = start // Lone %
+				break scan
+			}
+		}
+	}
+
+	return verbs, true
+}
+
+func checkPrintf(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func) {
+	kind, ok := formatMethods[fn.Name()]
+	if !ok || len(call.Args) == 0 || call.Ellipsis.IsValid() {
+		return
+	}
+
+	format, ok := constantString(pass, call.Args[0])
+	if !ok {
+		return
+	}
+
+	verbs, ok := parseVerbs(format)
+	if !ok {
+		return
+	}
+
+	args := call.Args[1:]
+
+	if kind == printlnFormat {
+		if len(verbs) > 0 {
+			pass.Reportf(call.Args[0].Pos(), "%s call has possible formatting directive %s", fn.Name(), format[verbs[0].pos:])
+		}
+		return
+	}
+
+	if kind == autoFormat && len(verbs) == 0 {
+		return // Arguments only message
+	}
+
+	needed := 0
+	for _, v := range verbs {
+		needed += v.args
+	}
+
+	if needed != len(args) {
+		pass.Reportf(call.Pos(), "%s format %q reads %d args, but call has %d args", fn.Name(), format, needed, len(args))
+		return
+	}
+
+	argn := 0
+	for _, v := range verbs {
+		argn += v.args
+		arg := args[argn-1]
+		if t := pass.TypesInfo.TypeOf(arg); t != nil && !verbAccepts(v.char, t) {
+			pass.Reportf(arg.Pos(), "%s format %%%c has arg %s of wrong type %s", fn.Name(), v.char, types.ExprString(arg), t)
+		}
+	}
+}
+
+var (
+	errorType    = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	stringerType = types.NewInterfaceType([]*types.Func{
+		types.NewFunc(0, nil, "String", types.NewSignatureType(nil, nil, nil, nil, types.NewTuple(types.NewVar(0, nil, "", types.Typ[types.String])), false)),
+	}, nil).Complete()
+)
+
+// verbAccepts returns if the verb can format a value of type t. Only basic types are checked
+func verbAccepts(v byte, t types.Type) bool {
+	if types.Implements(t, errorType) || types.Implements(t, stringerType) {
+		return true
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return true
+	}
+
+	info := basic.Info()
+	switch v {
+	case 'd', 'b', 'o', 'O', 'c', 'U':
+		return info&types.IsInteger != 0 || (v == 'b' && info&types.IsFloat != 0)
+	case 'x', 'X':
+		return info&(types.IsInteger|types.IsFloat|types.IsString|types.IsComplex) != 0
+	case 's':
+		return info&types.IsString != 0
+	case 'q':
+		return info&(types.IsString|types.IsInteger) != 0
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+		return info&(types.IsFloat|types.IsComplex) != 0
+	case 't':
+		return info&types.IsBoolean != 0
+	}
+
+	return true
+}
@@ -0,0 +1,193 @@
+// Package slogcheck implements a static analyzer that reports common mistakes when using the slog library.
+//
+// It checks:
+//   - printf verbs and arguments of Info, Warn, Error, Debug (and their operation sugars and f/ln variants)
+//   - Await calls without a matching Done or Success on the same instance in the same function
+//   - WithFields map literals using keys reserved by the log output (msg, time, scope, ...)
+//   - Operation(X).Level chains that should use the corresponding sugar (WarnAwait, ErrorDone, ...)
+//
+// It can be run with go vet:
+//
+//	go install github.com/quan-to/slog/slogcheck/cmd/slogcheck@latest
+//	go vet -vettool=$(which slogcheck) ./...
+package slogcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const slogPath = "github.com/quan-to/slog"
+
+// Analyzer reports common mistakes when using the slog library
+var Analyzer = &analysis.Analyzer{
+	Name:     "slogcheck",
+	Doc:      "check for common mistakes when using github.com/quan-to/slog",
+	URL:      "https://pkg.go.dev/github.com/quan-to/slog/slogcheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// New returns the analyzers of this package. It follows the golangci-lint Go plugin signature
+func New(conf any) ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{Analyzer}, nil
+}
+
+var reservedFields = map[string]bool{
+	"time":      true,
+	"scope":     true,
+	"op":        true,
+	"tag":       true,
+	"level":     true,
+	"msg":       true,
+	"lines":     true,
+	"caller":    true,
+	"goroutine": true,
+	"pid":       true,
+	"error":     true,
+}
+
+// operationSugars maps a level method and a operation to the corresponding sugar method
+var operationSugars = map[string]map[string]string{
+	"Info": {
+		"AWAIT": "Await",
+		"DONE":  "Done",
+		"NOTE":  "Note",
+		"IO":    "IO",
+	},
+	"Warn": {
+		"AWAIT": "WarnAwait",
+		"DONE":  "WarnDone",
+		"NOTE":  "WarnNote",
+		"IO":    "WarnIO",
+	},
+	"Error": {
+		"AWAIT": "ErrorAwait",
+		"DONE":  "ErrorDone",
+		"NOTE":  "ErrorNote",
+		"IO":    "ErrorIO",
+	},
+	"Debug": {
+		"AWAIT": "DebugAwait",
+		"DONE":  "DebugDone",
+		"NOTE":  "DebugNote",
+		"IO":    "DebugIO",
+	},
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn := slogFunc(pass, call)
+		if fn == nil {
+			return
+		}
+
+		checkPrintf(pass, call, fn)
+
+		switch fn.Name() {
+		case "WithFields":
+			checkReservedFields(pass, call)
+		case "Info", "Warn", "Error", "Debug":
+			checkOperationSugar(pass, call, fn)
+		}
+	})
+
+	ins.Preorder([]ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}, func(n ast.Node) {
+		checkAwait(pass, n)
+	})
+
+	return nil, nil
+}
+
+// slogFunc returns the slog function or method called, or nil if the call is not to the slog package
+func slogFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != slogPath {
+		return nil
+	}
+	return fn
+}
+
+func checkReservedFields(pass *analysis.Pass, call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+
+	lit, ok := call.Args[0].(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		key, ok := constantString(pass, kv.Key)
+		if ok && reservedFields[key] {
+			pass.Reportf(kv.Key.Pos(), "WithFields key %q is reserved by the log output and will be overwritten", key)
+		}
+	}
+}
+
+func checkOperationSugar(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	opCall, ok := sel.X.(*ast.CallExpr)
+	if !ok || len(opCall.Args) != 1 {
+		return
+	}
+
+	opFn := slogFunc(pass, opCall)
+	opSel, ok := opCall.Fun.(*ast.SelectorExpr)
+	if opFn == nil || opFn.Name() != "Operation" || !ok {
+		return
+	}
+
+	op, ok := constantString(pass, opCall.Args[0])
+	if !ok {
+		return
+	}
+
+	sugar := operationSugars[fn.Name()][op]
+	if sugar == "" {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     opSel.Sel.Pos(),
+		End:     sel.Sel.End(),
+		Message: "use " + sugar + " instead of Operation(" + op + ")." + fn.Name(),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Replace with " + sugar,
+			TextEdits: []analysis.TextEdit{{
+				Pos:     opSel.Sel.Pos(),
+				End:     sel.Sel.End(),
+				NewText: []byte(sugar),
+			}},
+		}},
+	})
+}
+
+func constantString(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	s, err := strconv.Unquote(tv.Value.ExactString())
+	return s, err == nil
+}
@@ -0,0 +1,76 @@
+package a
+
+import "github.com/quan-to/slog"
+
+var log = slog.Scope("A")
+
+func printf(name string, count int) {
+	log.Info("User %s has %d items", name, count)
+	log.Info("100% done")
+	log.Info("Args only", name, count)
+	log.Info("User %s has %d items", name) // want `Info format "User %s has %d items" reads 2 args, but call has 1 args`
+	log.Warn("Count is %d", name)          // want `Warn format %d has arg name of wrong type string`
+	log.Infof("Done", count)               // want `Infof format "Done" reads 0 args, but call has 1 args`
+	log.Infoln("Count %d", count)          // want `Infoln call has possible formatting directive %d`
+	slog.Info("%s %s", name)               // want `Info format "%s %s" reads 2 args, but call has 1 args`
+	_ = log.Errorf("Cannot save %s: %w", name, errFailed)
+	_ = log.Errorf("Cannot save %d", name) // want `Errorf format %d has arg name of wrong type string`
+}
+
+var errFailed error
+
+func awaitDone() {
+	l := log.SubScope("awaitDone")
+	l.Await("Working")
+	l.Done("Finished")
+}
+
+func awaitDerived() {
+	l := log.SubScope("awaitDerived")
+	l.Await("Working")
+	l.SubScope("Child").Success("Finished")
+}
+
+func awaitAssigned() {
+	a := log.Await("Working")
+	a.ErrorDone("Failed")
+}
+
+func awaitDeferred() {
+	l := log.SubScope("awaitDeferred")
+	l.Await("Working")
+	defer func() {
+		l.Done("Finished")
+	}()
+}
+
+func awaitMissing() {
+	l := log.SubScope("awaitMissing")
+	l.Await("Working") // want `Await without a matching Done or Success on the same instance`
+	log.Done("Other instance")
+}
+
+func awaitWarn() {
+	log.WarnAwait("Working") // want `WarnAwait without a matching Done or Success on the same instance`
+}
+
+func reserved() {
+	log.WithFields(map[string]interface{}{
+		"msg":   "value", // want `WithFields key "msg" is reserved by the log output and will be overwritten`
+		"user":  "huebr",
+		"level": 1, // want `WithFields key "level" is reserved by the log output and will be overwritten`
+	}).Info("Reserved")
+
+	log.WithFields(map[string]interface{}{
+		"caller":    "main.go:10", // want `WithFields key "caller" is reserved by the log output and will be overwritten`
+		"goroutine": 1,            // want `WithFields key "goroutine" is reserved by the log output and will be overwritten`
+		"pid":       1,            // want `WithFields key "pid" is reserved by the log output and will be overwritten`
+		"error":     "failed",     // want `WithFields key "error" is reserved by the log output and will be overwritten`
+	}).Info("Reserved")
+}
+
+func sugars() {
+	log.Operation(slog.AWAIT).Warn("Working") // want `use WarnAwait instead of Operation\(AWAIT\).Warn`
+	log.Operation(slog.DONE).Info("Finished") // want `use Done instead of Operation\(DONE\).Info`
+	log.Operation(slog.MSG).Info("Message")
+}
@@ -0,0 +1,36 @@
+// Package slog is a stub of github.com/quan-to/slog for the analyzer tests
+package slog
+
+type LogOperation string
+
+const (
+	MSG   LogOperation = "MSG"
+	IO                 = "IO"
+	AWAIT              = "AWAIT"
+	DONE               = "DONE"
+	NOTE               = "NOTE"
+)
+
+type Instance interface {
+	SubScope(string) Instance
+	WithFields(map[string]interface{}) Instance
+	Operation(LogOperation) Instance
+
+	Info(str interface{}, v ...interface{}) Instance
+	Warn(str interface{}, v ...interface{}) Instance
+	Error(str interface{}, v ...interface{}) Instance
+	Debug(str interface{}, v ...interface{}) Instance
+	Infof(format string, v ...interface{}) Instance
+	Infoln(v ...interface{}) Instance
+	Errorf(format string, v ...interface{}) error
+
+	Await(interface{}, ...interface{}) Instance
+	Done(interface{}, ...interface{}) Instance
+	Success(interface{}, ...interface{}) Instance
+	WarnAwait(interface{}, ...interface{}) Instance
+	ErrorDone(interface{}, ...interface{}) Instance
+}
+
+func Scope(scope string) Instance { return nil }
+
+func Info(str interface{}, v ...interface{}) Instance { return nil }
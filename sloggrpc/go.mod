@@ -0,0 +1,17 @@
+module github.com/quan-to/slog/sloggrpc
+
+go 1.24.0
+
+require (
+	github.com/quan-to/slog v0.1.0
+	google.golang.org/grpc v1.80.0
+)
+
+require (
+	github.com/logrusorgru/aurora v0.0.0-20190803045625-94edacc10f9b // indirect
+	golang.org/x/net v0.49.0 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+	golang.org/x/text v0.33.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260120221211-b8f7ae30c516 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+)
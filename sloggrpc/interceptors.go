@@ -0,0 +1,253 @@
+package sloggrpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/quan-to/slog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultTagKey is the metadata key of the request tag when no other is set
+const DefaultTagKey = "x-slog-tag"
+
+// Options are the options of the interceptors
+type Options struct {
+	// TagKey is the metadata key of the request tag. Defaults to DefaultTagKey
+	TagKey string
+	// NewTag generates the tag of calls without one. Defaults to 16 random hex characters
+	NewTag func() string
+}
+
+type tagContextKey struct{}
+
+// TagFromContext returns the request tag of the call, set by the server interceptors
+func TagFromContext(ctx context.Context) string {
+	tag, _ := ctx.Value(tagContextKey{}).(string)
+	return tag
+}
+
+func (o *Options) tagKey() string {
+	if o == nil || o.TagKey == "" {
+		return DefaultTagKey
+	}
+	return o.TagKey
+}
+
+func (o *Options) newTag() string {
+	if o != nil && o.NewTag != nil {
+		return o.NewTag()
+	}
+
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// serverCall returns the context and instance of a call received by the server, with the tag from the incoming metadata
+func serverCall(ctx context.Context, inst slog.Instance, method string, opts *Options) (context.Context, slog.Instance) {
+	tag := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(opts.tagKey()); len(v) > 0 {
+			tag = v[0]
+		}
+	}
+
+	if tag == "" {
+		tag = opts.newTag()
+	}
+
+	l := inst.Tag(tag).SubScope(method)
+	ctx = context.WithValue(ctx, tagContextKey{}, tag)
+	return slog.NewContext(ctx, l), l
+}
+
+// clientCall returns the context and instance of a call made by the client, with the tag in the outgoing metadata.
+// The tag is the one already in the outgoing metadata or the one of the server call being handled, if any
+func clientCall(ctx context.Context, inst slog.Instance, method string, opts *Options) (context.Context, slog.Instance) {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if v := md.Get(opts.tagKey()); len(v) > 0 && v[0] != "" {
+			return ctx, inst.Tag(v[0]).SubScope(method)
+		}
+	}
+
+	tag := TagFromContext(ctx)
+	if tag == "" {
+		tag = opts.newTag()
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, opts.tagKey(), tag)
+	return ctx, inst.Tag(tag).SubScope(method)
+}
+
+// done logs the end of a call, in a level that depends on its status code
+func done(l slog.Instance, start time.Time, err error, fields map[string]interface{}) {
+	code := status.Code(err)
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	fields["code"] = code.String()
+	fields["latency"] = time.Since(start).String()
+	if err != nil {
+		fields["error"] = err
+	}
+
+	l = l.WithFields(fields)
+	switch code {
+	case codes.OK:
+		l.Done("Call finished")
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.Unauthenticated, codes.FailedPrecondition, codes.OutOfRange, codes.ResourceExhausted:
+		l.WarnDone("Call failed with %s", code)
+	default:
+		l.ErrorDone("Call failed with %s", code)
+	}
+}
+
+// UnaryServerInterceptor returns a server interceptor that logs each call with a instance scoped by the method and tagged by the request tag.
+// The instance is available to the handler with slog.FromContext
+func UnaryServerInterceptor(inst slog.Instance, opts *Options) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, l := serverCall(ctx, inst, info.FullMethod, opts)
+		start := time.Now()
+
+		l.Await("Call started")
+		resp, err := handler(ctx, req)
+		done(l, start, err, nil)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor that logs each stream like UnaryServerInterceptor, and its message counts
+func StreamServerInterceptor(inst slog.Instance, opts *Options) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, l := serverCall(ss.Context(), inst, info.FullMethod, opts)
+		start := time.Now()
+
+		stream := &serverStream{ServerStream: ss, ctx: ctx}
+		l.Await("Stream started")
+		err := handler(srv, stream)
+
+		l.IO("%d messages received, %d sent", stream.received, stream.sent)
+		done(l, start, err, map[string]interface{}{"received": stream.received, "sent": stream.sent})
+		return err
+	}
+}
+
+// UnaryClientInterceptor returns a client interceptor that logs each call and sends the request tag in the metadata
+func UnaryClientInterceptor(inst slog.Instance, opts *Options) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		ctx, l := clientCall(ctx, inst, method, opts)
+		start := time.Now()
+
+		l.Await("Call started")
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		done(l, start, err, nil)
+
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a client interceptor that logs each stream and its message counts, and sends the request tag in the metadata.
+// The stream end is logged when receiving a message fails, including with io.EOF
+func StreamClientInterceptor(inst slog.Instance, opts *Options) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, l := clientCall(ctx, inst, method, opts)
+		start := time.Now()
+
+		l.Await("Stream started")
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			done(l, start, err, nil)
+			return nil, err
+		}
+
+		return &clientStream{ClientStream: cs, l: l, start: start}, nil
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	mu       sync.Mutex
+	received int
+	sent     int
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.mu.Lock()
+		s.received++
+		s.mu.Unlock()
+	}
+	return err
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.mu.Lock()
+		s.sent++
+		s.mu.Unlock()
+	}
+	return err
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	l        slog.Instance
+	start    time.Time
+	once     sync.Once
+	mu       sync.Mutex
+	received int
+	sent     int
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.mu.Lock()
+		s.received++
+		s.mu.Unlock()
+		return nil
+	}
+
+	s.once.Do(func() {
+		end := err
+		if errors.Is(err, io.EOF) {
+			end = nil
+		}
+
+		s.mu.Lock()
+		received, sent := s.received, s.sent
+		s.mu.Unlock()
+
+		s.l.IO("%d messages received, %d sent", received, sent)
+		done(s.l, s.start, end, map[string]interface{}{"received": received, "sent": sent})
+	})
+	return err
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.mu.Lock()
+		s.sent++
+		s.mu.Unlock()
+	}
+	return err
+}
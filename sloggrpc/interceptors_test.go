@@ -0,0 +1,235 @@
+package sloggrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/quan-to/slog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// syncBuffer is a bytes.Buffer safe to be written by the server and read by the test
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// entries returns the logged JSON lines
+func (b *syncBuffer) entries(t *testing.T) []map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), slog.LineBreak) {
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Invalid JSON line %q: %s", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	tag     string
+	handler slog.Instance
+}
+
+func (s *healthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	s.tag = TagFromContext(ctx)
+	s.handler = slog.FromContext(ctx)
+	if req.Service == "missing" {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *healthServer) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	s.tag = TagFromContext(stream.Context())
+	for n := 0; n < 3; n++ {
+		if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startServer starts an in-process server with the interceptors and returns a client connected to it
+func startServer(t *testing.T, srv *healthServer, serverOut, clientOut *syncBuffer, opts *Options) grpc_health_v1.HealthClient {
+	lis := bufconn.Listen(1024 * 1024)
+	serverLog := slog.Scope("Server").WithCustomWriter(serverOut)
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(serverLog, opts)),
+		grpc.StreamInterceptor(StreamServerInterceptor(serverLog, opts)),
+	)
+	grpc_health_v1.RegisterHealthServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	clientLog := slog.Scope("Client").WithCustomWriter(clientOut)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(clientLog, opts)),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(clientLog, opts)),
+	)
+	if err != nil {
+		t.Fatalf("Error creating client: %s", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func checkEntry(t *testing.T, entry map[string]interface{}, level, op, scope, tag string) {
+	t.Helper()
+	if entry["level"] != level || entry["op"] != op || entry["scope"] != scope || entry["tag"] != tag {
+		t.Errorf("Expected level %s, op %s, scope %q and tag %s got %v", level, op, scope, tag, entry)
+	}
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	slog.UnsetTestMode()
+	slog.SetLogFormat(slog.JSON)
+	defer slog.SetLogFormat(slog.PIPE)
+
+	srv := &healthServer{}
+	serverOut, clientOut := &syncBuffer{}, &syncBuffer{}
+	client := startServer(t, srv, serverOut, clientOut, &Options{NewTag: func() string { return "tag-1" }})
+
+	if _, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if srv.tag != "tag-1" {
+		t.Errorf("Expected the client tag in the server got %q", srv.tag)
+	}
+
+	if srv.handler == nil || srv.handler == slog.FromContext(context.Background()) {
+		t.Errorf("Expected the call instance in the handler context")
+	}
+
+	method := "/grpc.health.v1.Health/Check"
+	for _, c := range []struct {
+		out   *syncBuffer
+		scope string
+	}{{serverOut, "Server - " + method}, {clientOut, "Client - " + method}} {
+		entries := c.out.entries(t)
+		if len(entries) != 2 {
+			t.Fatalf("Expected 2 lines got %v", entries)
+		}
+		checkEntry(t, entries[0], "info", "AWAIT", c.scope, "tag-1")
+		checkEntry(t, entries[1], "info", "DONE", c.scope, "tag-1")
+		if entries[1]["code"] != "OK" || entries[1]["latency"] == nil {
+			t.Errorf("Expected code and latency fields got %v", entries[1])
+		}
+	}
+}
+
+func TestUnaryInterceptorsError(t *testing.T) {
+	slog.UnsetTestMode()
+	slog.SetLogFormat(slog.JSON)
+	defer slog.SetLogFormat(slog.PIPE)
+
+	srv := &healthServer{}
+	serverOut, clientOut := &syncBuffer{}, &syncBuffer{}
+	client := startServer(t, srv, serverOut, clientOut, &Options{TagKey: "x-request-id"})
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected NotFound got %v", err)
+	}
+
+	if len(srv.tag) != 16 {
+		t.Errorf("Expected a generated tag got %q", srv.tag)
+	}
+
+	for _, out := range []*syncBuffer{serverOut, clientOut} {
+		entries := out.entries(t)
+		if len(entries) != 2 {
+			t.Fatalf("Expected 2 lines got %v", entries)
+		}
+		if entries[1]["level"] != "warn" || entries[1]["op"] != "DONE" || entries[1]["code"] != "NotFound" || entries[1]["tag"] != srv.tag {
+			t.Errorf("Expected a NotFound warning with tag %s got %v", srv.tag, entries[1])
+		}
+		if e, ok := entries[1]["error"].(map[string]interface{}); !ok || !strings.Contains(e["message"].(string), "unknown service") {
+			t.Errorf("Expected the error field got %v", entries[1])
+		}
+	}
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	slog.UnsetTestMode()
+	slog.SetLogFormat(slog.JSON)
+	defer slog.SetLogFormat(slog.PIPE)
+
+	srv := &healthServer{}
+	serverOut, clientOut := &syncBuffer{}, &syncBuffer{}
+	client := startServer(t, srv, serverOut, clientOut, &Options{NewTag: func() string { return "tag-2" }})
+
+	stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	received := 0
+	for {
+		if _, err := stream.Recv(); err != nil {
+			break
+		}
+		received++
+	}
+
+	if received != 3 || srv.tag != "tag-2" {
+		t.Fatalf("Expected 3 messages with tag tag-2 got %d with tag %q", received, srv.tag)
+	}
+
+	method := "/grpc.health.v1.Health/Watch"
+	for _, c := range []struct {
+		out      *syncBuffer
+		scope    string
+		received float64
+		sent     float64
+	}{{serverOut, "Server - " + method, 1, 3}, {clientOut, "Client - " + method, 3, 1}} {
+		entries := c.out.entries(t)
+		if len(entries) != 3 {
+			t.Fatalf("Expected 3 lines got %v", entries)
+		}
+		checkEntry(t, entries[0], "info", "AWAIT", c.scope, "tag-2")
+		checkEntry(t, entries[1], "info", "IO", c.scope, "tag-2")
+		checkEntry(t, entries[2], "info", "DONE", c.scope, "tag-2")
+		if entries[2]["code"] != "OK" || entries[2]["received"] != c.received || entries[2]["sent"] != c.sent {
+			t.Errorf("Expected code OK, %v received and %v sent got %v", c.received, c.sent, entries[2])
+		}
+	}
+}
+
+func TestClientTagNotRepeated(t *testing.T) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), DefaultTagKey, "tag-0")
+	for n := 0; n < 2; n++ {
+		ctx, _ = clientCall(ctx, slog.Scope("Client"), "/grpc.health.v1.Health/Check", nil)
+	}
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if tags := md.Get(DefaultTagKey); len(tags) != 1 || tags[0] != "tag-0" {
+		t.Errorf("Expected a single tag in the outgoing metadata got %v", tags)
+	}
+}
@@ -0,0 +1,115 @@
+// Package sloggrpc integrates gRPC with the slog library.
+//
+// NewLoggerV2 implements a grpclog.LoggerV2 on top of a slog Instance, so the gRPC internal logs come out in the slog format:
+//
+//	grpclog.SetLoggerV2(sloggrpc.NewLoggerV2(slog.Scope("gRPC"), 0))
+package sloggrpc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quan-to/slog"
+	"google.golang.org/grpc/grpclog"
+)
+
+// depthSkip is the number of frames between a logger method and its caller, as the logger is called through the grpclog functions.
+// Same as in the gRPC glog logger
+const depthSkip = 2
+
+type loggerV2 struct {
+	inst      slog.Instance
+	verbosity int
+}
+
+// NewLoggerV2 returns a grpclog.LoggerV2 that logs through the instance.
+// The verbosity is the maximum level accepted by V, like the GRPC_GO_LOG_VERBOSITY_LEVEL environment variable
+func NewLoggerV2(inst slog.Instance, verbosity int) grpclog.LoggerV2 {
+	return &loggerV2{inst: inst, verbosity: verbosity}
+}
+
+// log logs the message. skip is the number of frames to skip above the caller of log, when looking for the caller to show
+func (l *loggerV2) log(level slog.LogLevel, skip int, msg string) {
+	l.inst.WithCallerSkip(skip+1).LogAt(level, "%s", msg)
+}
+
+// fatal logs the message in FATAL level and closes the program. skip is the same as in log
+func (l *loggerV2) fatal(skip int, msg string) {
+	l.inst.WithCallerSkip(skip+1).Fatal("%s", msg)
+}
+
+func sprintln(args []interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
+
+func (l *loggerV2) Info(args ...interface{}) {
+	l.log(slog.INFO, depthSkip, fmt.Sprint(args...))
+}
+
+func (l *loggerV2) Infoln(args ...interface{}) {
+	l.log(slog.INFO, depthSkip, sprintln(args))
+}
+
+func (l *loggerV2) Infof(format string, args ...interface{}) {
+	l.log(slog.INFO, depthSkip, fmt.Sprintf(format, args...))
+}
+
+func (l *loggerV2) Warning(args ...interface{}) {
+	l.log(slog.WARN, depthSkip, fmt.Sprint(args...))
+}
+
+func (l *loggerV2) Warningln(args ...interface{}) {
+	l.log(slog.WARN, depthSkip, sprintln(args))
+}
+
+func (l *loggerV2) Warningf(format string, args ...interface{}) {
+	l.log(slog.WARN, depthSkip, fmt.Sprintf(format, args...))
+}
+
+func (l *loggerV2) Error(args ...interface{}) {
+	l.log(slog.ERROR, depthSkip, fmt.Sprint(args...))
+}
+
+func (l *loggerV2) Errorln(args ...interface{}) {
+	l.log(slog.ERROR, depthSkip, sprintln(args))
+}
+
+func (l *loggerV2) Errorf(format string, args ...interface{}) {
+	l.log(slog.ERROR, depthSkip, fmt.Sprintf(format, args...))
+}
+
+func (l *loggerV2) Fatal(args ...interface{}) {
+	l.fatal(depthSkip, fmt.Sprint(args...))
+}
+
+func (l *loggerV2) Fatalln(args ...interface{}) {
+	l.fatal(depthSkip, sprintln(args))
+}
+
+func (l *loggerV2) Fatalf(format string, args ...interface{}) {
+	l.fatal(depthSkip, fmt.Sprintf(format, args...))
+}
+
+func (l *loggerV2) V(level int) bool {
+	return level <= l.verbosity
+}
+
+// InfoDepth logs the arguments, handled as in fmt.Println, at the specified call depth
+func (l *loggerV2) InfoDepth(depth int, args ...interface{}) {
+	l.log(slog.INFO, depth+depthSkip, sprintln(args))
+}
+
+// WarningDepth logs the arguments, handled as in fmt.Println, at the specified call depth
+func (l *loggerV2) WarningDepth(depth int, args ...interface{}) {
+	l.log(slog.WARN, depth+depthSkip, sprintln(args))
+}
+
+// ErrorDepth logs the arguments, handled as in fmt.Println, at the specified call depth
+func (l *loggerV2) ErrorDepth(depth int, args ...interface{}) {
+	l.log(slog.ERROR, depth+depthSkip, sprintln(args))
+}
+
+// FatalDepth logs the arguments, handled as in fmt.Println, at the specified call depth and closes the program
+func (l *loggerV2) FatalDepth(depth int, args ...interface{}) {
+	l.fatal(depth+depthSkip, sprintln(args))
+}
@@ -0,0 +1,73 @@
+package sloggrpc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/quan-to/slog"
+	"google.golang.org/grpc/grpclog"
+)
+
+func currentLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func TestLoggerV2(t *testing.T) {
+	slog.UnsetTestMode()
+	slog.SetShowLines(true)
+	defer slog.SetShowLines(false)
+
+	buff := bytes.NewBufferString("")
+	slog.SetWriterColorMode(buff, slog.ColorNever)
+	l := NewLoggerV2(slog.Scope("gRPC").WithCustomWriter(buff), 1)
+	grpclog.SetLoggerV2(l)
+	defer grpclog.SetLoggerV2(grpclog.NewLoggerV2(io.Discard, io.Discard, io.Discard))
+
+	grpclog.Infof("channel %d created", 1)
+	infoLine := currentLine() - 1
+	grpclog.Errorln("transport", "closed")
+	errorLine := currentLine() - 1
+	grpclog.Component("core").Warningf("retrying %d", 2)
+	depthLine := currentLine() - 1
+
+	lines := strings.Split(strings.TrimSpace(buff.String()), slog.LineBreak)
+	expected := []string{
+		fmt.Sprintf("| I | MSG   | NONE | gRPC                     | logger_test.go:%d | channel 1 created |", infoLine),
+		fmt.Sprintf("| E | MSG   | NONE | gRPC                     | logger_test.go:%d | transport closed |", errorLine),
+		fmt.Sprintf("| W | MSG   | NONE | gRPC                     | logger_test.go:%d | [core] retrying 2 |", depthLine),
+	}
+
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines got %q", len(expected), lines)
+	}
+
+	for n, e := range expected {
+		if !strings.Contains(lines[n], e) {
+			t.Errorf("Expected %q in %q", e, lines[n])
+		}
+	}
+
+	if !l.V(1) || l.V(2) {
+		t.Errorf("Expected verbosity 1")
+	}
+}
+
+func TestLoggerV2Fatal(t *testing.T) {
+	slog.UnsetTestMode()
+
+	code := -1
+	slog.SetExitFunc(func(c int) { code = c })
+	defer slog.SetExitFunc(nil)
+
+	buff := bytes.NewBufferString("")
+	NewLoggerV2(slog.Scope("gRPC").WithCustomWriter(buff), 0).Fatal("server ", "stopped")
+
+	if code != 1 || !strings.Contains(buff.String(), "server stopped") {
+		t.Errorf("Expected fatal message and exit, got code %d and %q", code, buff.String())
+	}
+}
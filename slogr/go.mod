@@ -0,0 +1,10 @@
+module github.com/quan-to/slog/slogr
+
+go 1.24.0
+
+require (
+	github.com/go-logr/logr v1.4.3
+	github.com/quan-to/slog v0.1.0
+)
+
+require github.com/logrusorgru/aurora v0.0.0-20190803045625-94edacc10f9b // indirect
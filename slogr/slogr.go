@@ -0,0 +1,102 @@
+// Package slogr implements a go-logr LogSink on top of a slog Instance.
+//
+// V-levels are mapped onto the slog levels: V(0) is INFO, V(1) is DEBUG and V(2) and above are TRACE.
+// Names are mapped onto sub scopes and key-values onto fields.
+//
+//	log := logr.New(slogr.NewLogSink(slog.Scope("Controller")))
+package slogr
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/quan-to/slog"
+)
+
+// noValue is the value of a key without a value in the key-values list
+const noValue = "<no-value>"
+
+type sink struct {
+	inst      slog.Instance
+	callDepth int
+}
+
+// NewLogSink returns a logr.LogSink that logs through the instance
+func NewLogSink(inst slog.Instance) logr.LogSink {
+	return &sink{inst: inst}
+}
+
+// New returns a logr.Logger that logs through the instance
+func New(inst slog.Instance) logr.Logger {
+	return logr.New(NewLogSink(inst))
+}
+
+// Level returns the slog level of a logr V-level
+func Level(v int) slog.LogLevel {
+	switch {
+	case v <= 0:
+		return slog.INFO
+	case v == 1:
+		return slog.DEBUG
+	}
+	return slog.TRACE
+}
+
+func (s *sink) Init(info logr.RuntimeInfo) {
+	s.callDepth = info.CallDepth
+}
+
+func (s *sink) Enabled(level int) bool {
+	return slog.LevelEnabled(Level(level))
+}
+
+func (s *sink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.instance(keysAndValues).LogAt(Level(level), "%s", msg)
+}
+
+func (s *sink) Error(err error, msg string, keysAndValues ...interface{}) {
+	inst := s.instance(keysAndValues)
+	if err != nil {
+		inst = inst.WithFields(map[string]interface{}{"error": err})
+	}
+	inst.LogAt(slog.ERROR, "%s", msg)
+}
+
+func (s *sink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &sink{inst: withValues(s.inst, keysAndValues), callDepth: s.callDepth}
+}
+
+func (s *sink) WithName(name string) logr.LogSink {
+	return &sink{inst: s.inst.SubScope(name), callDepth: s.callDepth}
+}
+
+func (s *sink) WithCallDepth(depth int) logr.LogSink {
+	return &sink{inst: s.inst, callDepth: s.callDepth + depth}
+}
+
+// instance returns the instance to log a message, skipping the sink and logr frames when looking for the caller
+func (s *sink) instance(keysAndValues []interface{}) slog.Instance {
+	return withValues(s.inst, keysAndValues).WithCallerSkip(s.callDepth + 1)
+}
+
+func withValues(inst slog.Instance, keysAndValues []interface{}) slog.Instance {
+	if len(keysAndValues) == 0 {
+		return inst
+	}
+
+	fields := make(map[string]interface{}, (len(keysAndValues)+1)/2)
+	for n := 0; n < len(keysAndValues); n += 2 {
+		key, ok := keysAndValues[n].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[n])
+		}
+
+		var value interface{} = noValue
+		if n+1 < len(keysAndValues) {
+			value = keysAndValues[n+1]
+		}
+		fields[key] = value
+	}
+
+	return inst.WithFields(fields)
+}
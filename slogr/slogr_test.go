@@ -0,0 +1,101 @@
+package slogr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/quan-to/slog"
+)
+
+func logJSON(t *testing.T, f func(buff *bytes.Buffer) int) (map[string]interface{}, int) {
+	slog.SetLogFormat(slog.JSON)
+	slog.SetShowLines(true)
+	defer slog.SetLogFormat(slog.PIPE)
+	defer slog.SetShowLines(false)
+
+	buff := bytes.NewBufferString("")
+	line := f(buff)
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(buff.Bytes(), &values); err != nil {
+		t.Fatalf("Cannot parse output %q: %s", buff.String(), err)
+	}
+	return values, line
+}
+
+func currentLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func TestLogSink(t *testing.T) {
+	slog.UnsetTestMode()
+
+	values, line := logJSON(t, func(buff *bytes.Buffer) int {
+		log := New(slog.Scope("Controller").WithCustomWriter(buff))
+		log.WithName("reconciler").WithValues("pod", "web-1").V(1).Info("Reconciling", "attempt", 2, "odd")
+		return currentLine() - 1
+	})
+
+	expected := map[string]interface{}{
+		"level":   "debug",
+		"scope":   "Controller - reconciler",
+		"msg":     "Reconciling",
+		"pod":     "web-1",
+		"attempt": float64(2),
+		"odd":     noValue,
+		"lines":   fmt.Sprintf("slogr_test.go:%d", line),
+	}
+
+	for k, v := range expected {
+		if values[k] != v {
+			t.Errorf("Expected %s to be %v got %v", k, v, values[k])
+		}
+	}
+}
+
+func TestLogSinkError(t *testing.T) {
+	slog.UnsetTestMode()
+
+	values, _ := logJSON(t, func(buff *bytes.Buffer) int {
+		New(slog.Scope("Controller").WithCustomWriter(buff)).Error(errors.New("not found"), "Cannot get pod", "pod", "web-1")
+		return 0
+	})
+
+	if values["level"] != "error" || values["msg"] != "Cannot get pod" || values["pod"] != "web-1" {
+		t.Errorf("Unexpected error output %v", values)
+	}
+
+	e, _ := values["error"].(map[string]interface{})
+	if e["message"] != "not found" {
+		t.Errorf("Expected error field got %v", values["error"])
+	}
+}
+
+func TestLevels(t *testing.T) {
+	slog.UnsetTestMode()
+	slog.SetTrace(false)
+
+	cases := map[int]slog.LogLevel{0: slog.INFO, 1: slog.DEBUG, 2: slog.TRACE, 5: slog.TRACE}
+	for v, level := range cases {
+		if Level(v) != level {
+			t.Errorf("Expected %s for V(%d) got %s", level, v, Level(v))
+		}
+	}
+
+	log := New(slog.Scope("Controller"))
+	if !log.V(1).Enabled() || log.V(2).Enabled() {
+		t.Errorf("Expected V(1) to be enabled and V(2) disabled")
+	}
+
+	buff := bytes.NewBufferString("")
+	New(slog.Scope("Controller").WithCustomWriter(buff)).V(3).Info("hidden")
+	if strings.Contains(buff.String(), "hidden") {
+		t.Errorf("Expected disabled V-level to not be logged")
+	}
+}
@@ -0,0 +1,224 @@
+package slogsql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+type conn struct {
+	driver.Conn
+	l *logger
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext prepares the statement, logging only if it fails
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	start := time.Now()
+
+	var s driver.Stmt
+	var err error
+	if pc, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		s, err = pc.PrepareContext(ctx, query)
+	} else {
+		s, err = c.Conn.Prepare(query)
+	}
+
+	if err != nil {
+		c.l.log(ctx, start, err, nil, query)
+		return nil, err
+	}
+	return &stmt{Stmt: s, l: c.l, query: query}, nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	start := time.Now()
+
+	var t driver.Tx
+	var err error
+	if bc, ok := c.Conn.(driver.ConnBeginTx); ok {
+		t, err = bc.BeginTx(ctx, opts)
+	} else if opts.Isolation != driver.IsolationLevel(0) {
+		err = errors.New("slogsql: driver does not support non-default isolation level")
+	} else if opts.ReadOnly {
+		err = errors.New("slogsql: driver does not support read-only transactions")
+	} else {
+		t, err = c.Conn.Begin()
+	}
+
+	c.l.log(ctx, start, err, nil, "BEGIN")
+	if err != nil {
+		return nil, err
+	}
+	return &tx{Tx: t, l: c.l, ctx: ctx}, nil
+}
+
+// ExecContext runs the statement if the driver implements driver.ExecerContext. Otherwise database/sql prepares it
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ec, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	res, err := ec.ExecContext(ctx, query, args)
+	c.l.log(ctx, start, err, resultFields(c.l.argFields(args), res, err), query)
+	return res, err
+}
+
+// QueryContext runs the query if the driver implements driver.QueryerContext. Otherwise database/sql prepares it
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	qc, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	rows, err := qc.QueryContext(ctx, query, args)
+	c.l.log(ctx, start, err, c.l.argFields(args), query)
+	return rows, err
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if nc, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return nc.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// resultFields adds the rows affected by an exec to the fields
+func resultFields(fields map[string]interface{}, res driver.Result, err error) map[string]interface{} {
+	if err != nil || res == nil {
+		return fields
+	}
+
+	if rows, err := res.RowsAffected(); err == nil {
+		fields["rows"] = rows
+	}
+	return fields
+}
+
+type stmt struct {
+	driver.Stmt
+	l     *logger
+	query string
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+
+	var res driver.Result
+	var err error
+	if ec, ok := s.Stmt.(driver.StmtExecContext); ok {
+		res, err = ec.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = plainValues(args); err == nil {
+			res, err = s.Stmt.Exec(values)
+		}
+	}
+
+	s.l.log(ctx, start, err, resultFields(s.l.argFields(args), res, err), s.query)
+	return res, err
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+
+	var rows driver.Rows
+	var err error
+	if qc, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = qc.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = plainValues(args); err == nil {
+			rows, err = s.Stmt.Query(values)
+		}
+	}
+
+	s.l.log(ctx, start, err, s.l.argFields(args), s.query)
+	return rows, err
+}
+
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if nc, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return nc.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for n, arg := range args {
+		named[n] = driver.NamedValue{Ordinal: n + 1, Value: arg}
+	}
+	return named
+}
+
+func plainValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for n, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("slogsql: driver does not support the use of named parameters")
+		}
+		values[n] = arg.Value
+	}
+	return values, nil
+}
+
+type tx struct {
+	driver.Tx
+	l   *logger
+	ctx context.Context
+}
+
+func (t *tx) Commit() error {
+	start := time.Now()
+	err := t.Tx.Commit()
+	t.l.log(t.ctx, start, err, nil, "COMMIT")
+	return err
+}
+
+func (t *tx) Rollback() error {
+	start := time.Now()
+	err := t.Tx.Rollback()
+	t.l.log(t.ctx, start, err, nil, "ROLLBACK")
+	return err
+}
@@ -0,0 +1,188 @@
+// Package slogsql wraps database/sql drivers to log each statement and transaction as an IO operation.
+//
+// The statements are logged through the instance carried by the context (see slog.NewContext), or the wrapper instance if there is none:
+//
+//	db := sql.OpenDB(slogsql.WrapConnector(connector, slog.Scope("DB"), &slogsql.Options{SlowThreshold: time.Second}))
+package slogsql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"path"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/quan-to/slog"
+)
+
+// Options are the options of the wrappers
+type Options struct {
+	// SlowThreshold is the duration from which a statement is logged in WARN level. Zero disables it
+	SlowThreshold time.Duration
+	// LogArgs sets if the argument values are logged. By default only their count is logged
+	LogArgs bool
+}
+
+// pkgDir is the directory of the package source files
+var pkgDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return path.Dir(file)
+}()
+
+type logger struct {
+	inst slog.Instance
+	opts Options
+}
+
+func newLogger(inst slog.Instance, opts *Options) *logger {
+	l := &logger{inst: inst}
+	if opts != nil {
+		l.opts = *opts
+	}
+	return l
+}
+
+// instance returns the instance carried by ctx, or the wrapper instance
+func (l *logger) instance(ctx context.Context) slog.Instance {
+	if inst, ok := slog.LookupContext(ctx); ok {
+		return inst
+	}
+	return l.inst
+}
+
+// argFields returns the fields of a statement arguments
+func (l *logger) argFields(args []driver.NamedValue) map[string]interface{} {
+	if !l.opts.LogArgs {
+		return map[string]interface{}{"args": len(args)}
+	}
+
+	values := make([]interface{}, len(args))
+	for n, arg := range args {
+		values[n] = arg.Value
+	}
+	return map[string]interface{}{"args": values}
+}
+
+// log logs a finished operation, in WARN level if slow or with ErrorIO if failed. driver.ErrSkip is not logged, as the operation is retried
+func (l *logger) log(ctx context.Context, start time.Time, err error, fields map[string]interface{}, msg string) {
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+
+	elapsed := time.Since(start)
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	fields["duration"] = elapsed.String()
+	if err != nil {
+		fields["error"] = err
+	}
+
+	inst := l.instance(ctx).WithCallerSkip(callerSkip()).WithFields(fields)
+	switch {
+	case err != nil:
+		inst.ErrorIO("%s", msg)
+	case l.opts.SlowThreshold > 0 && elapsed >= l.opts.SlowThreshold:
+		inst.WarnIO("%s", msg)
+	default:
+		inst.IO("%s", msg)
+	}
+}
+
+// callerSkip returns the number of frames of this package and database/sql from log, so the caller shown is the user of database/sql
+func callerSkip() int {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	skip := 0
+	for {
+		frame, more := frames.Next()
+		internal := path.Dir(frame.File) == pkgDir && !strings.HasSuffix(frame.File, "_test.go")
+		if !internal && !strings.HasPrefix(frame.Function, "database/sql.") {
+			return skip
+		}
+
+		skip++
+		if !more {
+			return skip
+		}
+	}
+}
+
+type wrappedDriver struct {
+	driver.Driver
+	l *logger
+}
+
+// Wrap returns a driver that logs the statements and transactions of the driver connections.
+// It can be registered with sql.Register
+func Wrap(d driver.Driver, inst slog.Instance, opts *Options) driver.Driver {
+	return &wrappedDriver{Driver: d, l: newLogger(inst, opts)}
+}
+
+func (d *wrappedDriver) Open(name string) (driver.Conn, error) {
+	c, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: c, l: d.l}, nil
+}
+
+func (d *wrappedDriver) OpenConnector(name string) (driver.Connector, error) {
+	if dc, ok := d.Driver.(driver.DriverContext); ok {
+		c, err := dc.OpenConnector(name)
+		if err != nil {
+			return nil, err
+		}
+		return &connector{Connector: c, d: d}, nil
+	}
+	return &connector{Connector: dsnConnector{name: name, d: d.Driver}, d: d}, nil
+}
+
+// dsnConnector is the connector of drivers that do not implement driver.DriverContext
+type dsnConnector struct {
+	name string
+	d    driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.d.Open(c.name)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.d
+}
+
+type connector struct {
+	driver.Connector
+	d *wrappedDriver
+}
+
+// WrapConnector returns a connector that logs the statements and transactions of the connector connections.
+// It can be opened with sql.OpenDB
+func WrapConnector(c driver.Connector, inst slog.Instance, opts *Options) driver.Connector {
+	return &connector{Connector: c, d: &wrappedDriver{Driver: c.Driver(), l: newLogger(inst, opts)}}
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	dc, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: dc, l: c.d.l}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.d
+}
+
+// Close closes the connector if it is an io.Closer, as done by sql.DB.Close
+func (c *connector) Close() error {
+	if closer, ok := c.Connector.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package slogsql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/quan-to/slog"
+)
+
+func currentLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+// fakeDriver is a driver whose statements affect 3 rows and return no rows.
+// Statements starting with FAIL fail and statements starting with SLOW take 5ms
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{}, nil
+}
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{}, nil
+}
+
+func (fakeConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeConn struct{}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	return run(query)
+}
+
+func run(query string) (driver.Result, error) {
+	switch {
+	case strings.HasPrefix(query, "FAIL"):
+		return nil, errors.New("syntax error")
+	case strings.HasPrefix(query, "SLOW"):
+		time.Sleep(5 * time.Millisecond)
+	}
+	return driver.RowsAffected(3), nil
+}
+
+type fakeStmt struct {
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return run(s.query)
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	if _, err := run(s.query); err != nil {
+		return nil, err
+	}
+	return fakeRows{}, nil
+}
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string {
+	return []string{"id"}
+}
+
+func (fakeRows) Close() error {
+	return nil
+}
+
+func (fakeRows) Next([]driver.Value) error {
+	return io.EOF
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error {
+	return nil
+}
+
+func (fakeTx) Rollback() error {
+	return errors.New("connection lost")
+}
+
+func entries(t *testing.T, buff *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buff.String()), slog.LineBreak) {
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Invalid JSON line %q: %s", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func checkEntry(t *testing.T, entry map[string]interface{}, level, op, msg string) {
+	t.Helper()
+	if entry["level"] != level || entry["op"] != op || entry["msg"] != msg || entry["duration"] == nil {
+		t.Errorf("Expected level %s, op %s, msg %q and duration got %v", level, op, msg, entry)
+	}
+}
+
+func TestWrapConnector(t *testing.T) {
+	slog.UnsetTestMode()
+	slog.SetLogFormat(slog.JSON)
+	defer slog.SetLogFormat(slog.PIPE)
+
+	buff := bytes.NewBufferString("")
+	ctxBuff := bytes.NewBufferString("")
+	db := sql.OpenDB(WrapConnector(fakeConnector{}, slog.Scope("DB").WithCustomWriter(buff), &Options{SlowThreshold: time.Millisecond}))
+	defer db.Close()
+
+	ctx := slog.NewContext(context.Background(), slog.Scope("Request").WithCustomWriter(ctxBuff))
+	if _, err := db.ExecContext(ctx, "UPDATE users SET name = ? WHERE id = ?", "john", 1); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	rows, err := db.Query("SELECT id FROM users WHERE name = ?", "john")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	_ = rows.Close()
+
+	if _, err := db.Exec("SLOW UPDATE users"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if _, err := db.Exec("FAIL"); err == nil {
+		t.Fatalf("Expected error")
+	}
+
+	ctxEntries := entries(t, ctxBuff)
+	if len(ctxEntries) != 1 {
+		t.Fatalf("Expected 1 line in the context instance got %v", ctxEntries)
+	}
+	checkEntry(t, ctxEntries[0], "info", "IO", "UPDATE users SET name = ? WHERE id = ?")
+	if ctxEntries[0]["scope"] != "Request" || ctxEntries[0]["args"] != float64(2) || ctxEntries[0]["rows"] != float64(3) {
+		t.Errorf("Expected scope Request, 2 args and 3 rows got %v", ctxEntries[0])
+	}
+
+	dbEntries := entries(t, buff)
+	if len(dbEntries) != 3 {
+		t.Fatalf("Expected 3 lines in the wrapper instance got %v", dbEntries)
+	}
+	checkEntry(t, dbEntries[0], "info", "IO", "SELECT id FROM users WHERE name = ?")
+	checkEntry(t, dbEntries[1], "warn", "IO", "SLOW UPDATE users")
+	checkEntry(t, dbEntries[2], "error", "IO", "FAIL")
+	if dbEntries[0]["args"] != float64(1) || dbEntries[0]["rows"] != nil {
+		t.Errorf("Expected 1 arg and no rows got %v", dbEntries[0])
+	}
+	if e, ok := dbEntries[2]["error"].(map[string]interface{}); !ok || e["message"] != "syntax error" {
+		t.Errorf("Expected the error field got %v", dbEntries[2])
+	}
+}
+
+func TestWrapTransactions(t *testing.T) {
+	slog.UnsetTestMode()
+	slog.SetLogFormat(slog.JSON)
+	defer slog.SetLogFormat(slog.PIPE)
+
+	buff := bytes.NewBufferString("")
+	d := Wrap(fakeDriver{}, slog.Scope("DB").WithCustomWriter(buff), &Options{LogArgs: true})
+	c, err := d.(driver.DriverContext).OpenConnector("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := tx.Exec("DELETE FROM users WHERE id = ?", 7); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	tx, err = db.Begin()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := tx.Rollback(); err == nil {
+		t.Fatalf("Expected error")
+	}
+
+	lines := entries(t, buff)
+	if len(lines) != 5 {
+		t.Fatalf("Expected 5 lines got %v", lines)
+	}
+	checkEntry(t, lines[0], "info", "IO", "BEGIN")
+	checkEntry(t, lines[1], "info", "IO", "DELETE FROM users WHERE id = ?")
+	checkEntry(t, lines[2], "info", "IO", "COMMIT")
+	checkEntry(t, lines[3], "info", "IO", "BEGIN")
+	checkEntry(t, lines[4], "error", "IO", "ROLLBACK")
+
+	if fmt.Sprint(lines[1]["args"]) != "[7]" {
+		t.Errorf("Expected the argument values got %v", lines[1])
+	}
+}
+
+func TestWrapCaller(t *testing.T) {
+	slog.UnsetTestMode()
+	slog.SetShowLines(true)
+	defer slog.SetShowLines(false)
+
+	buff := bytes.NewBufferString("")
+	slog.SetWriterColorMode(buff, slog.ColorNever)
+	db := sql.OpenDB(WrapConnector(fakeConnector{}, slog.Scope("DB").WithCustomWriter(buff), nil))
+	defer db.Close()
+
+	_, _ = db.Exec("UPDATE users SET active = 1")
+	execLine := currentLine() - 1
+	_, _ = db.Query("SELECT 1")
+	queryLine := currentLine() - 1
+
+	lines := strings.Split(strings.TrimSpace(buff.String()), slog.LineBreak)
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines got %q", lines)
+	}
+
+	for n, line := range []int{execLine, queryLine} {
+		expected := fmt.Sprintf("| driver_test.go:%d |", line)
+		if !strings.Contains(lines[n], expected) {
+			t.Errorf("Expected %q in %q", expected, lines[n])
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package slog
+
+import (
+	"context"
+	stdslog "log/slog"
+	"sort"
+	"strings"
+)
+
+// HandlerOptions are the options of a log/slog Handler created by NewHandler
+type HandlerOptions struct {
+	// Level is the minimum log/slog level handled. When nil, the levels enabled in this library are used
+	Level stdslog.Leveler
+	// TagKey is the key of the attribute that sets the instance tag instead of a field. Disabled when empty
+	TagKey string
+	// OperationKey is the key of the attribute that sets the instance operation instead of a field. Disabled when empty
+	OperationKey string
+}
+
+// Handler is a log/slog Handler that logs through an Instance.
+// Groups are mapped to sub scopes and attributes to fields
+type Handler struct {
+	inst Instance
+	opts HandlerOptions
+}
+
+// NewHandler returns a log/slog Handler that logs through the instance. Use it with log/slog.New
+func NewHandler(inst Instance, opts *HandlerOptions) *Handler {
+	h := &Handler{inst: inst}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// Enabled returns if the level is handled
+func (h *Handler) Enabled(_ context.Context, level stdslog.Level) bool {
+	if h.opts.Level != nil {
+		return level >= h.opts.Level.Level()
+	}
+	return LevelEnabled(FromStdLevel(level))
+}
+
+// Handle logs the record through the instance
+func (h *Handler) Handle(_ context.Context, r stdslog.Record) error {
+	attrs := make([]stdslog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a stdslog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	h.with(attrs).LogAt(FromStdLevel(r.Level), "%s", r.Message)
+	return nil
+}
+
+// WithAttrs returns a handler with the attributes as fields
+func (h *Handler) WithAttrs(attrs []stdslog.Attr) stdslog.Handler {
+	return &Handler{inst: h.with(attrs), opts: h.opts}
+}
+
+// WithGroup returns a handler with the group as a sub scope
+func (h *Handler) WithGroup(name string) stdslog.Handler {
+	if name == "" {
+		return h
+	}
+	return &Handler{inst: h.inst.SubScope(name), opts: h.opts}
+}
+
+// with returns the instance with the attributes as fields, tag and operation
+func (h *Handler) with(attrs []stdslog.Attr) Instance {
+	inst := h.inst
+	fields := map[string]interface{}{}
+
+	for _, a := range attrs {
+		v := a.Value.Resolve()
+		switch {
+		case a.Key == "" && v.Kind() != stdslog.KindGroup:
+		case h.opts.TagKey != "" && a.Key == h.opts.TagKey:
+			inst = inst.Tag(v.String())
+		case h.opts.OperationKey != "" && a.Key == h.opts.OperationKey:
+			inst = inst.Operation(LogOperation(v.String()))
+		case v.Kind() == stdslog.KindGroup && a.Key == "":
+			for k, gv := range attrFields(v.Group()) {
+				fields[k] = gv
+			}
+		default:
+			fields[a.Key] = attrValue(v)
+		}
+	}
+
+	if len(fields) == 0 {
+		return inst
+	}
+	return inst.WithFields(fields)
+}
+
+func attrValue(v stdslog.Value) interface{} {
+	v = v.Resolve()
+	if v.Kind() == stdslog.KindGroup {
+		return attrFields(v.Group())
+	}
+	return v.Any()
+}
+
+func attrFields(attrs []stdslog.Attr) map[string]interface{} {
+	fields := make(map[string]interface{}, len(attrs))
+	for _, a := range attrs {
+		if a.Key != "" {
+			fields[a.Key] = attrValue(a.Value)
+		}
+	}
+	return fields
+}
+
+// FromStdLevel returns the level of this library for a log/slog level. FATAL and PANIC are never returned
+func FromStdLevel(level stdslog.Level) LogLevel {
+	switch {
+	case level < stdslog.LevelDebug:
+		return TRACE
+	case level < stdslog.LevelInfo:
+		return DEBUG
+	case level < stdslog.LevelInfo+2:
+		return INFO
+	case level < stdslog.LevelWarn:
+		return NOTICE
+	case level < stdslog.LevelError:
+		return WARN
+	}
+	return ERROR
+}
+
+// ToStdLevel returns the log/slog level for a level of this library. Custom levels use the level of the built-in level right below them
+func ToStdLevel(level LogLevel) stdslog.Level {
+	severity := LevelSeverity(level)
+	switch {
+	case severity < LevelSeverity(DEBUG):
+		return stdslog.LevelDebug - 4
+	case severity < LevelSeverity(INFO):
+		return stdslog.LevelDebug
+	case severity < LevelSeverity(NOTICE):
+		return stdslog.LevelInfo
+	case severity < LevelSeverity(WARN):
+		return stdslog.LevelInfo + 2
+	case severity < LevelSeverity(ERROR):
+		return stdslog.LevelWarn
+	case severity < LevelSeverity(PANIC):
+		return stdslog.LevelError
+	case severity < LevelSeverity(FATAL):
+		return stdslog.LevelError + 4
+	}
+	return stdslog.LevelError + 8
+}
+
+// WithHandler returns a new instance that emits its log lines through the log/slog handler instead of its output.
+// The scope, tag and operation are added as attributes, along with the fields
+func (i *slogInstance) WithHandler(h stdslog.Handler) Instance {
+	i2 := i.clone()
+	i2.handler = h
+	return i2
+}
+
+// handle emits a log call through the log/slog handler of the instance
+func (i *slogInstance) handle(str interface{}, level LogLevel, v []interface{}) {
+	ctx := context.Background()
+	stdLevel := ToStdLevel(level)
+	if !i.handler.Enabled(ctx, stdLevel) {
+		return
+	}
+
+	r := stdslog.NewRecord(i.now(), stdLevel, message(str, v...), 0)
+	if frames := callerFrames(callerStack(), i.callerSkip, 1); len(frames) > 0 && !i.noCaller {
+		r.PC = frames[0].PC
+	}
+
+	r.AddAttrs(
+		stdslog.String("scope", strings.Join(i.scope, " > ")),
+		stdslog.String("tag", i.tag),
+		stdslog.String("op", string(i.op)),
+	)
+
+	fields := i.logFields()
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		r.AddAttrs(stdslog.Any(k, fields[k]))
+	}
+
+	_ = i.handler.Handle(ctx, r)
+}
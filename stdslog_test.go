@@ -0,0 +1,126 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stdslog "log/slog"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	UnsetTestMode()
+	SetLogFormat(JSON)
+	defer SetLogFormat(PIPE)
+
+	buff := bytes.NewBufferString("")
+	logger := stdslog.New(NewHandler(Scope("Std").WithCustomWriter(buff), &HandlerOptions{TagKey: "request", OperationKey: "op"}))
+
+	logger.With("user", "u1").WithGroup("db").Warn("query 100% done", "rows", 3, "request", "R1", "op", "IO", stdslog.Group("conn", "host", "db1"))
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(buff.Bytes(), &values); err != nil {
+		t.Fatalf("Cannot parse output %q: %s", buff.String(), err)
+	}
+
+	expected := map[string]interface{}{
+		"msg":   "query 100% done",
+		"level": "warn",
+		"scope": "Std - db",
+		"tag":   "R1",
+		"op":    "IO",
+		"user":  "u1",
+		"rows":  float64(3),
+		"conn":  map[string]interface{}{"host": "db1"},
+	}
+
+	for k, v := range expected {
+		a, _ := json.Marshal(values[k])
+		b, _ := json.Marshal(v)
+		if string(a) != string(b) {
+			t.Errorf("Expected %s to be %s got %s", k, b, a)
+		}
+	}
+}
+
+func TestHandlerEnabled(t *testing.T) {
+	UnsetTestMode()
+
+	h := NewHandler(Scope("Std"), nil)
+	if h.Enabled(context.Background(), stdslog.LevelDebug-4) {
+		t.Errorf("Expected TRACE to be disabled")
+	}
+
+	if !h.Enabled(context.Background(), stdslog.LevelInfo) {
+		t.Errorf("Expected INFO to be enabled")
+	}
+
+	h = NewHandler(Scope("Std"), &HandlerOptions{Level: stdslog.LevelWarn})
+	if h.Enabled(context.Background(), stdslog.LevelInfo) {
+		t.Errorf("Expected INFO to be disabled by the handler level")
+	}
+}
+
+func TestStdLevels(t *testing.T) {
+	cases := map[stdslog.Level]LogLevel{
+		stdslog.LevelDebug - 4: TRACE,
+		stdslog.LevelDebug:     DEBUG,
+		stdslog.LevelInfo:      INFO,
+		stdslog.LevelInfo + 2:  NOTICE,
+		stdslog.LevelWarn:      WARN,
+		stdslog.LevelError:     ERROR,
+	}
+
+	for std, level := range cases {
+		if got := FromStdLevel(std); got != level {
+			t.Errorf("Expected %s for %s got %s", level, std, got)
+		}
+
+		if got := ToStdLevel(level); got != std {
+			t.Errorf("Expected %s for %s got %s", std, level, got)
+		}
+	}
+
+	var fatal LogLevel = FATAL
+	if ToStdLevel(fatal) <= ToStdLevel(ERROR) {
+		t.Errorf("Expected FATAL to be above ERROR")
+	}
+}
+
+func TestWithHandler(t *testing.T) {
+	UnsetTestMode()
+
+	buff := bytes.NewBufferString("")
+	h := stdslog.NewJSONHandler(buff, &stdslog.HandlerOptions{AddSource: true})
+
+	i := Scope("Rev").Tag("T1").WithFields(map[string]interface{}{"user": "u1"}).WithHandler(h)
+	i.Warn("hello %s", "world")
+	line := currentLine() - 1
+	i.Debug("not handled")
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(buff.Bytes(), &values); err != nil {
+		t.Fatalf("Cannot parse output %q: %s", buff.String(), err)
+	}
+
+	expected := map[string]interface{}{
+		"msg":   "hello world",
+		"level": "WARN",
+		"scope": "Rev",
+		"tag":   "T1",
+		"op":    "MSG",
+		"user":  "u1",
+	}
+
+	for k, v := range expected {
+		if values[k] != v {
+			t.Errorf("Expected %s to be %v got %v", k, v, values[k])
+		}
+	}
+
+	source, _ := values["source"].(map[string]interface{})
+	if !strings.HasSuffix(source["file"].(string), "stdslog_test.go") || source["line"] != float64(line) {
+		t.Errorf("Expected source at stdslog_test.go:%d got %v", line, source)
+	}
+}
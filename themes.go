@@ -0,0 +1,164 @@
+package slog
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/logrusorgru/aurora"
+)
+
+// Color paints a text for the terminal
+type Color func(text string) string
+
+// AuroraColor converts an aurora color, like aurora.Red or aurora.BgBlue, to a Color
+func AuroraColor(c func(interface{}) aurora.Value) Color {
+	return func(text string) string {
+		return c(text).String()
+	}
+}
+
+// Color256 returns a foreground Color from the 256-color palette
+func Color256(n uint8) Color {
+	return func(text string) string {
+		return aurora.Index(n, text).String()
+	}
+}
+
+// BgColor256 returns a background Color from the 256-color palette
+func BgColor256(n uint8) Color {
+	return func(text string) string {
+		return aurora.BgIndex(n, text).String()
+	}
+}
+
+// Gray returns a foreground Color from the 24 grays of the 256-color palette, from black (0) to white (23)
+func Gray(n uint8) Color {
+	return func(text string) string {
+		return aurora.Gray(n, text).String()
+	}
+}
+
+// TrueColor returns a 24-bit foreground Color. Not all terminals support it
+func TrueColor(r, g, b uint8) Color {
+	return func(text string) string {
+		return fmt.Sprintf("\033[38;2;%d;%d;%dm%s\033[0m", r, g, b, text)
+	}
+}
+
+// BgTrueColor returns a 24-bit background Color. Not all terminals support it
+func BgTrueColor(r, g, b uint8) Color {
+	return func(text string) string {
+		return fmt.Sprintf("\033[48;2;%d;%d;%dm%s\033[0m", r, g, b, text)
+	}
+}
+
+// Theme defines the colors of the PIPE format output. Nil colors are not painted
+type Theme struct {
+	// Levels colors the level letter and the message. Levels not in the map use the color they were registered with
+	Levels map[LogLevel]Color
+	// Operations colors the operation. Operations not in the map use the color they were registered with
+	Operations map[LogOperation]Color
+	Date       Color
+	Tag        Color
+	Scope      Color
+	Pipe       Color
+}
+
+// DefaultTheme is the theme used when no other is set
+var DefaultTheme = Theme{
+	Date: Gray(7),
+	Tag:  Gray(7),
+	Pipe: func(text string) string {
+		return aurora.Bold(text).White().String()
+	},
+}
+
+var theme = DefaultTheme
+
+var hashedColors = false
+
+// hashPalette are the 256-color palette colors used for hashed scopes and tags, readable on dark and light terminals
+var hashPalette = []uint8{
+	27, 33, 39, 45, 38, 37, 36, 35, 41, 71, 77, 107, 113, 142, 172, 178,
+	166, 167, 168, 169, 170, 171, 134, 135, 98, 99, 63, 69, 75, 74, 73, 72,
+}
+
+// SetTheme sets the colors of the PIPE format output. Affects globally all SLog Instances
+func SetTheme(t Theme) {
+	theme = t
+}
+
+// SetHashedColors enables coloring each scope segment and tag with a stable color derived from its hash, overriding the theme colors for them
+func SetHashedColors(enabled bool) {
+	hashedColors = enabled
+}
+
+// HashedColorsEnabled returns if scopes and tags are colored by their hash
+func HashedColorsEnabled() bool {
+	return hashedColors
+}
+
+// HashColor returns the stable color of a text, as used by the hashed colors
+func HashColor(text string) Color {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(text))
+	return Color256(hashPalette[h.Sum32()%uint32(len(hashPalette))])
+}
+
+func paint(c Color, text string) string {
+	if c == nil {
+		return text
+	}
+	return c(text)
+}
+
+func (t Theme) levelColor(level LogLevel) Color {
+	if c := t.Levels[level]; c != nil {
+		return c
+	}
+	return AuroraColor(getColor(level))
+}
+
+func (t Theme) operation(op LogOperation, text string) string {
+	if c := t.Operations[op]; c != nil {
+		return c(text)
+	}
+	return operationColor(op)(text).White().String()
+}
+
+func (t Theme) tag(text string) string {
+	if hashedColors {
+		return hashPaint(text)
+	}
+	return paint(t.Tag, text)
+}
+
+// scope paints the scope column. With hashed colors, each segment has its own color
+func (t Theme) scope(text string) string {
+	if !hashedColors {
+		return paint(t.Scope, text)
+	}
+
+	segments := strings.Split(text, " > ")
+	for n, s := range segments {
+		segments[n] = hashPaint(s)
+	}
+	return strings.Join(segments, " > ")
+}
+
+// hashPaint paints the text with its hashed color, keeping the padding spaces unpainted
+func hashPaint(text string) string {
+	return paintTrimmed(HashColor(strings.TrimSpace(text)), text)
+}
+
+// paintTrimmed paints the text, keeping the surrounding spaces unpainted
+func paintTrimmed(c Color, text string) string {
+	trimmed := strings.TrimSpace(text)
+	if c == nil || trimmed == "" {
+		return text
+	}
+
+	start := strings.Index(text, trimmed)
+	return text[:start] + c(trimmed) + text[start+len(trimmed):]
+}
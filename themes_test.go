@@ -0,0 +1,80 @@
+package slog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSetTheme(t *testing.T) {
+	UnsetTestMode()
+	SetScopeLength(24)
+	defer SetShowLines(showLines)
+	SetShowLines(false)
+	defer SetTheme(DefaultTheme)
+
+	buff := bytes.NewBufferString("")
+	SetWriterColorMode(buff, ColorAlways)
+
+	SetTheme(Theme{
+		Levels:     map[LogLevel]Color{INFO: TrueColor(1, 2, 3)},
+		Operations: map[LogOperation]Color{DONE: BgColor256(22)},
+		Pipe:       Color256(240),
+	})
+
+	Scope("Theme").WithCustomWriter(buff).Done("Themed")
+	o := buff.String()
+
+	for _, expected := range []string{"\033[38;2;1;2;3mThemed", "\033[48;5;22mDONE", "\033[38;5;240m|"} {
+		if !strings.Contains(o, expected) {
+			t.Errorf("Expected %q in output: %q", expected, o)
+		}
+	}
+
+	if strings.HasPrefix(o, "\033") {
+		t.Errorf("Expected date without colors: %q", o)
+	}
+
+	if !strings.Contains(stripColors(o), "| I | DONE  | NONE | Theme                    | Themed  | {}") {
+		t.Errorf("Expected theme to keep the layout: %q", stripColors(o))
+	}
+}
+
+func TestHashedColors(t *testing.T) {
+	UnsetTestMode()
+	SetScopeLength(24)
+	defer SetShowLines(showLines)
+	SetShowLines(false)
+	defer SetHashedColors(false)
+
+	buff := bytes.NewBufferString("")
+	SetWriterColorMode(buff, ColorAlways)
+	SetHashedColors(true)
+
+	if !HashedColorsEnabled() {
+		t.Errorf("Expected hashed colors to be enabled")
+	}
+
+	Scope("Server").SubScope("Request").Tag("REQ001").WithCustomWriter(buff).Info("Hashed")
+	o := buff.String()
+
+	for _, text := range []string{"Server", "Request", "REQ001"} {
+		if !strings.Contains(o, HashColor(text)(text)) {
+			t.Errorf("Expected %q with its hashed color in output: %q", text, o)
+		}
+	}
+
+	if !strings.Contains(stripColors(o), "| REQ001 | Server > Request         | Hashed") {
+		t.Errorf("Expected padded scope in output: %q", stripColors(o))
+	}
+}
+
+func TestHashColor(t *testing.T) {
+	if HashColor("REQ001")("x") != HashColor("REQ001")("x") {
+		t.Errorf("Expected hashed colors to be stable")
+	}
+
+	if HashColor("a")("x") == HashColor("b")("x") {
+		t.Errorf("Expected different texts to have different colors")
+	}
+}
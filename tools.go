@@ -3,21 +3,17 @@ package slog
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/logrusorgru/aurora"
-	"path"
 	"regexp"
-	"runtime"
+	"strconv"
 	"strings"
 	"time"
 )
 
-var pipeChar = aurora.Bold("|").White().String()
-
 func buildFieldString(data map[string]interface{}) string {
 	retVal := ""
 	switch fieldRepresentation {
 	case JSONFields:
-		retVal = buildJSON(data)
+		retVal = buildJSON(expandErrors(data))
 	case KeyValueFields:
 		for k, v := range data {
 			retVal += fmt.Sprintf("%s=%v,", k, v)
@@ -32,19 +28,119 @@ func buildJSON(data map[string]interface{}) string {
 	return string(v)
 }
 
-// formatTime returns the specified Date in ISO format (RFC3339)
+// formatTime returns the specified Date in the layout set by SetTimeFormat (RFC3339 by default)
 func formatTime(t time.Time) string {
-	return t.Format(time.RFC3339)
+	switch timeFormat {
+	case TimeUnixMilli:
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	case TimeUnixNano:
+		return strconv.FormatInt(t.UnixNano(), 10)
+	case TimeRelative:
+		return fmt.Sprintf("+%.3fs", t.Sub(clockStart).Seconds())
+	}
+
+	if timeLocation != nil {
+		t = t.In(timeLocation)
+	}
+	return t.Format(timeFormat)
+}
+
+// parseVerb parses the printf verb starting at the % in format[start].
+// Returns the verb end, how many arguments it consumes and if it has explicit argument indexes.
+// A % followed by a space, a non-letter verb or the end of the string is not a verb (end is -1)
+func parseVerb(format string, start int) (end, args int, explicit bool) {
+	n := start + 1
+	if n < len(format) && format[n] == '%' {
+		return n + 1, 0, false
+	}
+
+	for n < len(format) && strings.IndexByte("+-#0", format[n]) > -1 {
+		n++
+	}
+
+	args = 1
+	for n < len(format) {
+		c := format[n]
+		switch {
+		case c >= '0' && c <= '9', c == '.':
+		case c == '*':
+			args++
+		case c == '[':
+			explicit = true
+		case c == ']':
+		default:
+			if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+				return n + 1, args, explicit
+			}
+			return -1, 0, false
+		}
+		n++
+	}
+
+	return -1, 0, false
+}
+
+// countVerbs returns how many arguments the printf verbs in format consumes, or -1 if it uses explicit argument indexes
+func countVerbs(format string) int {
+	count := 0
+	for n := 0; n < len(format); n++ {
+		if format[n] != '%' {
+			continue
+		}
+
+		end, args, explicit := parseVerb(format, n)
+		if explicit {
+			return -1
+		}
+		if end > 0 {
+			count += args
+			n = end - 1
+		}
+	}
+
+	return count
 }
 
-func hasFormatData(str string) bool {
-	// TODO: Better testing
-	return strings.Index(str, "%") > -1
+// isFormat returns if the string must be formatted: it has verbs or %% escapes
+func isFormat(str string) bool {
+	return countVerbs(str) != 0 || strings.Contains(str, "%%")
 }
 
-func getCallerString(stackOffset int) string {
-	_, fn, line, _ := runtime.Caller(stackOffset)
-	return fmt.Sprintf("%s:%d", path.Base(fn), line)
+// fitFormat returns a printf format whose verbs match the number of arguments.
+// Lone % are escaped, verbs without a matching argument are kept as literal text and extra arguments are appended as %v
+func fitFormat(format string, nargs int) string {
+	if countVerbs(format) < 0 {
+		return format // Explicit argument indexes, trust the caller
+	}
+
+	b := strings.Builder{}
+	used := 0
+	for n := 0; n < len(format); n++ {
+		if format[n] != '%' {
+			b.WriteByte(format[n])
+			continue
+		}
+
+		end, args, _ := parseVerb(format, n)
+		switch {
+		case end < 0:
+			b.WriteString("%%")
+		case args > 0 && used+args > nargs:
+			b.WriteString("%" + format[n:end])
+			used = nargs
+			n = end - 1
+		default:
+			b.WriteString(format[n:end])
+			used += args
+			n = end - 1
+		}
+	}
+
+	for ; used < nargs; used++ {
+		b.WriteString(" %v")
+	}
+
+	return b.String()
 }
 
 func padRight(str string, length int) string {
@@ -55,6 +151,14 @@ func padRight(str string, length int) string {
 	return str
 }
 
+func padLeft(str string, length int) string {
+	for i := len(str); i < length; i++ {
+		str = " " + str
+	}
+
+	return str
+}
+
 func addPadding(str string, length int) string {
 	pad := ""
 	for i := 0; i < length; i++ {
@@ -1,6 +1,7 @@
 package slog
 
 import (
+	"fmt"
 	"github.com/logrusorgru/aurora"
 	"testing"
 )
@@ -30,3 +31,95 @@ func TestStringSliceIndexOf(t *testing.T) {
 		t.Errorf("Expected %s not found but got %d", v, stringSliceIndexOf(v, s))
 	}
 }
+
+func TestFitFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   string
+		args     []interface{}
+		expected string
+	}{
+		{
+			name:     "verbs match args",
+			format:   "Test %s %d %.2f",
+			args:     []interface{}{"huebr", 1, 10.0},
+			expected: "Test huebr 1 10.00",
+		},
+		{
+			name:     "lone percent with args",
+			format:   "100% done %s",
+			args:     []interface{}{"now"},
+			expected: "100% done now",
+		},
+		{
+			name:     "percent at the end",
+			format:   "%d%",
+			args:     []interface{}{100},
+			expected: "100%",
+		},
+		{
+			name:     "format without args",
+			format:   "%d items",
+			expected: "%d items",
+		},
+		{
+			name:     "missing args",
+			format:   "%s and %s",
+			args:     []interface{}{"a"},
+			expected: "a and %s",
+		},
+		{
+			name:     "extra args",
+			format:   "%s",
+			args:     []interface{}{"a", "b", 3},
+			expected: "a b 3",
+		},
+		{
+			name:     "escaped percent",
+			format:   "%d%% done",
+			args:     []interface{}{50},
+			expected: "50% done",
+		},
+		{
+			name:     "star width",
+			format:   "[%*d]",
+			args:     []interface{}{3, 1},
+			expected: "[  1]",
+		},
+		{
+			name:     "explicit indexes",
+			format:   "%[2]s %[1]s",
+			args:     []interface{}{"a", "b"},
+			expected: "b a",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := fmt.Sprintf(fitFormat(tc.format, len(tc.args)), tc.args...)
+			if result != tc.expected {
+				t.Errorf("Got %q want %q.", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCountVerbs(t *testing.T) {
+	testCases := map[string]int{
+		"no verbs":       0,
+		"100% done":      0,
+		"50%":            0,
+		"%% escaped":     0,
+		"%s %d":          2,
+		"%*.*f":          3,
+		"%[1]s":          -1,
+		"%+v and %#x":    2,
+		"%-10s|%08.3f|%": 2,
+	}
+
+	for format, expected := range testCases {
+		if c := countVerbs(format); c != expected {
+			t.Errorf("%q: got %d want %d", format, c, expected)
+		}
+	}
+}